@@ -0,0 +1,196 @@
+// Command gonum-bench records benchmark results as JSON and compares them against
+// a stored baseline to catch performance regressions.
+//
+// Usage:
+//
+//	gonum-bench run [-bench regexp] [-count n] [-benchtime d] [-o file] [packages]
+//	gonum-bench parse [-o file] < bench.txt
+//	gonum-bench compare [-threshold percent] baseline.json current.json
+//	gonum-bench tune [-max size] [-o file]
+//
+// run executes go test -bench for the packages, ./mat/... by default, and records the
+// results. parse records results from the saved output of go test -bench instead.
+// compare prints the change of every benchmark and exits with status 1 if any of them
+// got slower by more than the threshold, 10% by default.
+//
+// tune measures the Strassen crossover of every element type with the current GOMAXPROCS
+// and saves it to the default tuning file, see mat.TuningPath, or to -o. Dot only uses
+// the saved crossover in programs that opt in by calling mat.LoadTuning with that path
+// at startup.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+
+	"github.com/lattots/gonum/mat"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var err error
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "run":
+		err = run(args)
+	case "parse":
+		err = parse(args)
+	case "compare":
+		err = compare(args)
+	case "tune":
+		err = tune(args)
+	default:
+		usage()
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gonum-bench: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: gonum-bench run|parse|compare|tune [flags] [args]")
+	os.Exit(2)
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	bench := fs.String("bench", ".", "run only the benchmarks matching `regexp`")
+	count := fs.Int("count", 1, "run each benchmark `n` times")
+	benchtime := fs.String("benchtime", "1s", "run each benchmark for `duration` or Nx iterations")
+	out := fs.String("o", "", "write the results to `file` instead of standard output")
+	fs.Parse(args)
+
+	pkgs := fs.Args()
+	if len(pkgs) == 0 {
+		pkgs = []string{"./mat/..."}
+	}
+
+	goArgs := []string{"test", "-run", "^$", "-bench", *bench, "-benchmem", "-count", fmt.Sprint(*count), "-benchtime", *benchtime}
+	cmd := exec.Command("go", append(goArgs, pkgs...)...)
+
+	// Show progress while keeping a copy of the output to parse
+	var output bytes.Buffer
+	cmd.Stdout = io.MultiWriter(&output, os.Stderr)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("go test failed: %w", err)
+	}
+
+	res, err := Parse(&output)
+	if err != nil {
+		return err
+	}
+	return writeResults(res, *out)
+}
+
+func parse(args []string) error {
+	fs := flag.NewFlagSet("parse", flag.ExitOnError)
+	out := fs.String("o", "", "write the results to `file` instead of standard output")
+	fs.Parse(args)
+
+	res, err := Parse(os.Stdin)
+	if err != nil {
+		return err
+	}
+	return writeResults(res, *out)
+}
+
+func compare(args []string) error {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	threshold := fs.Float64("threshold", 10, "report benchmarks that got slower by more than `percent`")
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		return fmt.Errorf("compare needs a baseline and a current results file")
+	}
+	baseline, err := readResults(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	current, err := readResults(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	c := Compare(baseline, current, *threshold)
+	if err := c.Write(os.Stdout, *threshold); err != nil {
+		return err
+	}
+	if len(c.Regressions) > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
+
+func tune(args []string) error {
+	fs := flag.NewFlagSet("tune", flag.ExitOnError)
+	maxSize := fs.Int("max", 1024, "measure matrices up to `size` x size")
+	out := fs.String("o", "", "save the tuning to `file` instead of the default tuning file")
+	fs.Parse(args)
+
+	path := *out
+	if path == "" {
+		var err error
+		if path, err = mat.TuningPath(); err != nil {
+			return err
+		}
+	}
+
+	t := mat.TuneStrassen(*maxSize)
+
+	names := make([]string, 0, len(t.Thresholds))
+	for name := range t.Thresholds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Printf("Strassen crossover with GOMAXPROCS=%d:\n", t.Cores)
+	for _, name := range names {
+		if n := t.Thresholds[name]; n == mat.NoStrassen {
+			fmt.Printf("  %-8s never\n", name)
+		} else {
+			fmt.Printf("  %-8s %d\n", name, n)
+		}
+	}
+
+	if err := mat.SaveTuning(path, t); err != nil {
+		return err
+	}
+	fmt.Printf("saved to %s\n", path)
+	return nil
+}
+
+func readResults(path string) (*Results, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var res Results
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return &res, nil
+}
+
+func writeResults(res *Results, path string) error {
+	b, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	if path == "" {
+		_, err = os.Stdout.Write(b)
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Results is the JSON document recorded by the run and parse commands.
+type Results struct {
+	Date       time.Time   `json:"date"`
+	GOOS       string      `json:"goos,omitempty"`
+	GOARCH     string      `json:"goarch,omitempty"`
+	CPU        string      `json:"cpu,omitempty"`
+	Benchmarks []Benchmark `json:"benchmarks"`
+}
+
+// Benchmark is the summary of all runs of one benchmark. With -count above 1 the
+// values are medians, which are less sensitive to outliers than means.
+type Benchmark struct {
+	Package     string  `json:"package,omitempty"`
+	Name        string  `json:"name"`
+	Runs        int     `json:"runs"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	BytesPerOp  float64 `json:"bytes_per_op,omitempty"`
+	AllocsPerOp float64 `json:"allocs_per_op,omitempty"`
+}
+
+// key identifies a benchmark across result files.
+func (b Benchmark) key() string {
+	if b.Package == "" {
+		return b.Name
+	}
+	return b.Package + "." + b.Name
+}
+
+// benchLine matches a result line of go test -bench, with the -GOMAXPROCS suffix
+// of the name split off.
+var benchLine = regexp.MustCompile(`^(Benchmark\S*?)(?:-\d+)?\s+\d+\s+([0-9.e+]+) ns/op(?:\s+([0-9.e+]+) B/op)?(?:\s+([0-9.e+]+) allocs/op)?`)
+
+// Parse reads the output of go test -bench and summarizes the benchmarks in the
+// order they first appear. Lines that aren't benchmark results are ignored.
+func Parse(r io.Reader) (*Results, error) {
+	res := &Results{Date: time.Now().UTC()}
+
+	type samples struct {
+		bench             Benchmark
+		ns, bytes, allocs []float64
+	}
+	var order []string
+	byKey := make(map[string]*samples)
+
+	var pkg string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+
+		if k, v, ok := strings.Cut(line, ": "); ok {
+			switch k {
+			case "goos":
+				res.GOOS = v
+			case "goarch":
+				res.GOARCH = v
+			case "cpu":
+				res.CPU = v
+			case "pkg":
+				pkg = v
+			}
+		}
+
+		m := benchLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		b := Benchmark{Package: pkg, Name: m[1]}
+		s, ok := byKey[b.key()]
+		if !ok {
+			s = &samples{bench: b}
+			byKey[b.key()] = s
+			order = append(order, b.key())
+		}
+
+		for i, dst := range []*[]float64{&s.ns, &s.bytes, &s.allocs} {
+			if m[i+2] == "" {
+				continue
+			}
+			v, err := strconv.ParseFloat(m[i+2], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid benchmark result %q: %w", line, err)
+			}
+			*dst = append(*dst, v)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, k := range order {
+		s := byKey[k]
+		b := s.bench
+		b.Runs = len(s.ns)
+		b.NsPerOp = median(s.ns)
+		b.BytesPerOp = median(s.bytes)
+		b.AllocsPerOp = median(s.allocs)
+		res.Benchmarks = append(res.Benchmarks, b)
+	}
+
+	return res, nil
+}
+
+func median(v []float64) float64 {
+	if len(v) == 0 {
+		return 0
+	}
+	s := slices.Clone(v)
+	slices.Sort(s)
+	if len(s)%2 == 1 {
+		return s[len(s)/2]
+	}
+	return (s[len(s)/2-1] + s[len(s)/2]) / 2
+}
+
+// Change is the difference in time per operation of one benchmark between two results.
+type Change struct {
+	Name     string
+	Baseline float64
+	Current  float64
+	// Delta is the relative change in percent, positive when the benchmark got slower.
+	Delta float64
+}
+
+// Comparison holds the benchmarks present in both results along with the ones that
+// are only in one of them.
+type Comparison struct {
+	Changes     []Change
+	Regressions []Change
+	Missing     []string // only in the baseline
+	Added       []string // only in the current results
+}
+
+// Compare matches the benchmarks of current against baseline. A benchmark is a
+// regression if its time per operation grew by more than threshold percent.
+func Compare(baseline, current *Results, threshold float64) Comparison {
+	var c Comparison
+
+	base := make(map[string]Benchmark, len(baseline.Benchmarks))
+	for _, b := range baseline.Benchmarks {
+		base[b.key()] = b
+	}
+
+	seen := make(map[string]bool)
+	for _, cur := range current.Benchmarks {
+		k := cur.key()
+		seen[k] = true
+
+		b, ok := base[k]
+		if !ok {
+			c.Added = append(c.Added, k)
+			continue
+		}
+
+		ch := Change{Name: k, Baseline: b.NsPerOp, Current: cur.NsPerOp}
+		if b.NsPerOp > 0 {
+			ch.Delta = (cur.NsPerOp - b.NsPerOp) / b.NsPerOp * 100
+		} else if cur.NsPerOp > 0 {
+			ch.Delta = math.Inf(1)
+		}
+		c.Changes = append(c.Changes, ch)
+		if ch.Delta > threshold {
+			c.Regressions = append(c.Regressions, ch)
+		}
+	}
+
+	for _, b := range baseline.Benchmarks {
+		if !seen[b.key()] {
+			c.Missing = append(c.Missing, b.key())
+		}
+	}
+
+	sort.Slice(c.Regressions, func(i, j int) bool { return c.Regressions[i].Delta > c.Regressions[j].Delta })
+	return c
+}
+
+// Write prints the comparison as an aligned table followed by a summary.
+func (c Comparison) Write(w io.Writer, threshold float64) error {
+	width := len("benchmark")
+	for _, ch := range c.Changes {
+		width = max(width, len(ch.Name))
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%-*s  %14s  %14s  %8s\n", width, "benchmark", "baseline ns/op", "current ns/op", "delta")
+	for _, ch := range c.Changes {
+		mark := ""
+		if ch.Delta > threshold {
+			mark = "  REGRESSION"
+		}
+		fmt.Fprintf(bw, "%-*s  %14.1f  %14.1f  %+7.1f%%%s\n", width, ch.Name, ch.Baseline, ch.Current, ch.Delta, mark)
+	}
+
+	for _, name := range c.Missing {
+		fmt.Fprintf(bw, "missing from current results: %s\n", name)
+	}
+	for _, name := range c.Added {
+		fmt.Fprintf(bw, "not in baseline: %s\n", name)
+	}
+	fmt.Fprintf(bw, "%d of %d benchmarks regressed by more than %g%%\n", len(c.Regressions), len(c.Changes), threshold)
+
+	return bw.Flush()
+}
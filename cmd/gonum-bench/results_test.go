@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+const benchOutput = `goos: linux
+goarch: amd64
+pkg: github.com/lattots/gonum/mat
+cpu: Test CPU
+BenchmarkDot/float64/naive/128-8         	     300	   4000000 ns/op	  131072 B/op	       3 allocs/op
+BenchmarkDot/float64/naive/128-8         	     300	   5000000 ns/op	  131072 B/op	       3 allocs/op
+BenchmarkDot/float64/naive/128-8         	     300	   9000000 ns/op	  131072 B/op	       3 allocs/op
+BenchmarkTranspose/int/64                	  100000	     10000 ns/op
+PASS
+ok  	github.com/lattots/gonum/mat	3.000s
+`
+
+func TestParse(t *testing.T) {
+	start := time.Now()
+
+	res, err := Parse(strings.NewReader(benchOutput))
+	if err != nil {
+		t.Fatalf("Error parsing benchmark output: %v", err)
+	}
+	if res.GOOS != "linux" || res.GOARCH != "amd64" || res.CPU != "Test CPU" {
+		t.Errorf("Wrong metadata: %+v", res)
+	}
+	if len(res.Benchmarks) != 2 {
+		t.Fatalf("Wrong number of benchmarks. Want: 2, Got: %d", len(res.Benchmarks))
+	}
+
+	// Repeated runs are summarized by their median and the -8 suffix is dropped
+	expected := Benchmark{
+		Package:     "github.com/lattots/gonum/mat",
+		Name:        "BenchmarkDot/float64/naive/128",
+		Runs:        3,
+		NsPerOp:     5000000,
+		BytesPerOp:  131072,
+		AllocsPerOp: 3,
+	}
+	if res.Benchmarks[0] != expected {
+		t.Errorf("Wrong benchmark. Want: %+v, Got: %+v", expected, res.Benchmarks[0])
+	}
+	if b := res.Benchmarks[1]; b.Name != "BenchmarkTranspose/int/64" || b.NsPerOp != 10000 || b.BytesPerOp != 0 {
+		t.Errorf("Wrong benchmark without memory statistics: %+v", b)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestCompare(t *testing.T) {
+	start := time.Now()
+
+	baseline := &Results{Benchmarks: []Benchmark{
+		{Name: "BenchmarkA", NsPerOp: 100},
+		{Name: "BenchmarkB", NsPerOp: 100},
+		{Name: "BenchmarkC", NsPerOp: 100},
+	}}
+	current := &Results{Benchmarks: []Benchmark{
+		{Name: "BenchmarkA", NsPerOp: 109},
+		{Name: "BenchmarkB", NsPerOp: 125},
+		{Name: "BenchmarkD", NsPerOp: 50},
+	}}
+
+	c := Compare(baseline, current, 10)
+	if len(c.Changes) != 2 || len(c.Regressions) != 1 || c.Regressions[0].Name != "BenchmarkB" || c.Regressions[0].Delta != 25 {
+		t.Errorf("Wrong regressions with 10%% threshold: %+v", c)
+	}
+	if len(c.Missing) != 1 || c.Missing[0] != "BenchmarkC" || len(c.Added) != 1 || c.Added[0] != "BenchmarkD" {
+		t.Errorf("Wrong missing and added benchmarks: %+v", c)
+	}
+
+	// A larger threshold accepts the slowdown
+	if c := Compare(baseline, current, 30); len(c.Regressions) != 0 {
+		t.Errorf("Expected no regressions with 30%% threshold, got: %+v", c.Regressions)
+	}
+
+	var sb strings.Builder
+	c.Write(&sb, 10)
+	if out := sb.String(); !strings.Contains(out, "BenchmarkB") || !strings.Contains(out, "+25.0%  REGRESSION") || !strings.Contains(out, "1 of 2 benchmarks regressed") {
+		t.Errorf("Wrong comparison output:\n%s", out)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
@@ -0,0 +1,192 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/number"
+)
+
+// Quat is a quaternion W + Xi + Yj + Zk. Unit quaternions represent 3D rotations, with
+// q and -q giving the same rotation. Quaternions are small values and are passed by value.
+type Quat[T number.Float] struct {
+	W, X, Y, Z T
+}
+
+// QuatIdentity returns the quaternion of the identity rotation.
+func QuatIdentity[T number.Float]() Quat[T] {
+	return Quat[T]{W: 1}
+}
+
+// QuatFromAxisAngle returns the unit quaternion that rotates by theta about axis.
+// Panics if axis has length 0.
+func QuatFromAxisAngle[T number.Float](axis *mat.Mat[T], theta float64) Quat[T] {
+	u := vec3("an axis-angle rotation", mat.Normalize(axis))
+	s, c := math.Sincos(theta / 2)
+	return Quat[T]{W: T(c), X: T(s * u[0]), Y: T(s * u[1]), Z: T(s * u[2])}
+}
+
+// QuatFromMatrix returns the unit quaternion of the rotation matrix r, with W >= 0.
+// Panics if r is not 3x3.
+func QuatFromMatrix[T number.Float](r *mat.Mat[T]) Quat[T] {
+	m := mat3("a quaternion", r)
+
+	// Shepperd's method: solve for the largest component first, which is never small
+	var w, x, y, z float64
+	switch tr := m[0] + m[4] + m[8]; {
+	case tr > 0:
+		s := 2 * math.Sqrt(1+tr)
+		w, x, y, z = s/4, (m[7]-m[5])/s, (m[2]-m[6])/s, (m[3]-m[1])/s
+	case m[0] > m[4] && m[0] > m[8]:
+		s := 2 * math.Sqrt(1+m[0]-m[4]-m[8])
+		w, x, y, z = (m[7]-m[5])/s, s/4, (m[1]+m[3])/s, (m[2]+m[6])/s
+	case m[4] > m[8]:
+		s := 2 * math.Sqrt(1+m[4]-m[0]-m[8])
+		w, x, y, z = (m[2]-m[6])/s, (m[1]+m[3])/s, s/4, (m[5]+m[7])/s
+	default:
+		s := 2 * math.Sqrt(1+m[8]-m[0]-m[4])
+		w, x, y, z = (m[3]-m[1])/s, (m[2]+m[6])/s, (m[5]+m[7])/s, s/4
+	}
+
+	q := Quat[T]{W: T(w), X: T(x), Y: T(y), Z: T(z)}
+	if w < 0 {
+		q = q.Scale(-1)
+	}
+	return q.Normalize()
+}
+
+// QuatFromEuler returns the unit quaternion of the Euler angle rotation, see Euler.
+func QuatFromEuler[T number.Float](order EulerOrder, a, b, c float64) Quat[T] {
+	i, j, k, _ := order.axes()
+	return quatAbout[T](i, a).Mul(quatAbout[T](j, b)).Mul(quatAbout[T](k, c))
+}
+
+// quatAbout returns the rotation by theta about the x-, y- or z-axis for axis 0, 1 or 2.
+func quatAbout[T number.Float](axis int, theta float64) Quat[T] {
+	s, c := math.Sincos(theta / 2)
+	q := Quat[T]{W: T(c)}
+	switch axis {
+	case 0:
+		q.X = T(s)
+	case 1:
+		q.Y = T(s)
+	default:
+		q.Z = T(s)
+	}
+	return q
+}
+
+func (q Quat[T]) String() string {
+	return fmt.Sprintf("(%v + %vi + %vj + %vk)", q.W, q.X, q.Y, q.Z)
+}
+
+// Mul returns the Hamilton product q p, the rotation p followed by q.
+func (q Quat[T]) Mul(p Quat[T]) Quat[T] {
+	return Quat[T]{
+		W: q.W*p.W - q.X*p.X - q.Y*p.Y - q.Z*p.Z,
+		X: q.W*p.X + q.X*p.W + q.Y*p.Z - q.Z*p.Y,
+		Y: q.W*p.Y - q.X*p.Z + q.Y*p.W + q.Z*p.X,
+		Z: q.W*p.Z + q.X*p.Y - q.Y*p.X + q.Z*p.W,
+	}
+}
+
+// Scale multiplies every component of q by s.
+func (q Quat[T]) Scale(s T) Quat[T] {
+	return Quat[T]{W: q.W * s, X: q.X * s, Y: q.Y * s, Z: q.Z * s}
+}
+
+// Conj returns the conjugate of q, which is the inverse rotation for unit quaternions.
+func (q Quat[T]) Conj() Quat[T] {
+	return Quat[T]{W: q.W, X: -q.X, Y: -q.Y, Z: -q.Z}
+}
+
+// Dot calculates the 4D dot product of q and p.
+func (q Quat[T]) Dot(p Quat[T]) float64 {
+	return float64(q.W)*float64(p.W) + float64(q.X)*float64(p.X) + float64(q.Y)*float64(p.Y) + float64(q.Z)*float64(p.Z)
+}
+
+// Norm calculates the length of q.
+func (q Quat[T]) Norm() float64 {
+	return math.Sqrt(q.Dot(q))
+}
+
+// Normalize scales q to a length of 1. Panics if q has length 0.
+func (q Quat[T]) Normalize() Quat[T] {
+	n := q.Norm()
+	if n == 0 {
+		panic("geom: cannot normalize a quaternion of length 0")
+	}
+	return q.Scale(T(1 / n))
+}
+
+// Inverse returns the quaternion p with q p = 1. Panics if q has length 0.
+func (q Quat[T]) Inverse() Quat[T] {
+	n2 := q.Dot(q)
+	if n2 == 0 {
+		panic("geom: cannot invert a quaternion of length 0")
+	}
+	return q.Conj().Scale(T(1 / n2))
+}
+
+// Rotate rotates the 3-element vector v by the unit quaternion q and returns a
+// column vector. Panics if v doesn't have 3 elements.
+func (q Quat[T]) Rotate(v *mat.Mat[T]) *mat.Mat[T] {
+	u := vec3("a quaternion rotation", v)
+	p := q.Mul(Quat[T]{X: T(u[0]), Y: T(u[1]), Z: T(u[2])}).Mul(q.Conj())
+	return &mat.Mat[T]{M: 3, N: 1, Data: []T{p.X, p.Y, p.Z}}
+}
+
+// Matrix returns the 3x3 rotation matrix of the unit quaternion q.
+func (q Quat[T]) Matrix() *mat.Mat[T] {
+	w, x, y, z := float64(q.W), float64(q.X), float64(q.Y), float64(q.Z)
+	return fromFloat64[T](3, 3, []float64{
+		1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y),
+		2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x),
+		2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y),
+	})
+}
+
+// AxisAngle returns the unit axis and the angle in [0, π] of the rotation q. The axis
+// of a rotation by 0 is arbitrary, and the x-axis is returned.
+func (q Quat[T]) AxisAngle() (*mat.Mat[T], float64) {
+	q = q.Normalize()
+	if q.W < 0 {
+		q = q.Scale(-1)
+	}
+
+	s := math.Sqrt(float64(q.X)*float64(q.X) + float64(q.Y)*float64(q.Y) + float64(q.Z)*float64(q.Z))
+	theta := 2 * math.Atan2(s, float64(q.W))
+	if s == 0 {
+		return column[T](1, 0, 0), 0
+	}
+	return column[T](float64(q.X)/s, float64(q.Y)/s, float64(q.Z)/s), theta
+}
+
+// Slerp interpolates between the rotations q at t = 0 and p at t = 1 along the shortest
+// great arc, at a constant angular velocity. Both are normalized first. Rotations that
+// are nearly the same are interpolated linearly, where slerp is numerically unstable.
+func Slerp[T number.Float](q, p Quat[T], t float64) Quat[T] {
+	q, p = q.Normalize(), p.Normalize()
+
+	// q and -p are the same rotation, pick the one that is closer
+	cos := q.Dot(p)
+	if cos < 0 {
+		p, cos = p.Scale(-1), -cos
+	}
+
+	wq, wp := 1-t, t
+	if cos < 0.9995 {
+		theta := math.Acos(cos)
+		sin := math.Sin(theta)
+		wq, wp = math.Sin((1-t)*theta)/sin, math.Sin(t*theta)/sin
+	}
+
+	r := Quat[T]{
+		W: T(wq*float64(q.W) + wp*float64(p.W)),
+		X: T(wq*float64(q.X) + wp*float64(p.X)),
+		Y: T(wq*float64(q.Y) + wp*float64(p.Y)),
+		Z: T(wq*float64(q.Z) + wp*float64(p.Z)),
+	}
+	return r.Normalize()
+}
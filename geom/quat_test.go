@@ -0,0 +1,108 @@
+package geom_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/geom"
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+func TestQuatConversions(t *testing.T) {
+	start := time.Now()
+
+	rng := rand.New(rand.NewSource(1))
+	for range 20 {
+		r := mattest.RandomOrthogonal[float64](rng, 3)
+		if r.Data[0]*(r.Data[4]*r.Data[8]-r.Data[5]*r.Data[7])-r.Data[1]*(r.Data[3]*r.Data[8]-r.Data[5]*r.Data[6])+r.Data[2]*(r.Data[3]*r.Data[7]-r.Data[4]*r.Data[6]) < 0 {
+			// Reflections have no quaternion, flip one column to get a rotation
+			for i := range 3 {
+				r.Data[i*3] = -r.Data[i*3]
+			}
+		}
+
+		// Test case 1: Matrix to quaternion and back
+		q := geom.QuatFromMatrix(r)
+		if q.W < 0 || math.Abs(q.Norm()-1) > 1e-12 {
+			t.Errorf("Expected a unit quaternion with W >= 0, got %v", q)
+		}
+		mattest.AssertEqual(t, q.Matrix(), r, tol)
+
+		// Test case 2: Rotating a vector agrees with the matrix
+		v := vector(rng.Float64(), rng.Float64(), rng.Float64())
+		mattest.AssertEqual(t, q.Rotate(v), dot(t, r, v), tol)
+	}
+
+	// Test case 3: Axis-angle and Euler constructors agree with the matrices
+	q := geom.QuatFromAxisAngle(vector(1, 2, 2), 0.9)
+	mattest.AssertEqual(t, q.Matrix(), geom.AxisAngle(vector(1, 2, 2), 0.9), tol)
+	axis, theta := q.AxisAngle()
+	mattest.AssertEqual(t, axis, vector(1.0/3, 2.0/3, 2.0/3), tol)
+	if math.Abs(theta-0.9) > 1e-12 {
+		t.Errorf("Expected angle 0.9, got %g", theta)
+	}
+	for _, order := range []geom.EulerOrder{geom.XYZ, geom.ZYX, geom.YZX} {
+		qe := geom.QuatFromEuler[float64](order, 0.5, 1.1, -2)
+		mattest.AssertEqual(t, qe.Matrix(), geom.Euler[float64](order, 0.5, 1.1, -2), tol)
+	}
+
+	// Test case 4: Products compose rotations and the inverse undoes them
+	p := geom.QuatFromAxisAngle(vector(0, 0, 1), -0.4)
+	mattest.AssertEqual(t, q.Mul(p).Matrix(), dot(t, q.Matrix(), p.Matrix()), tol)
+	id := q.Scale(3).Mul(q.Scale(3).Inverse())
+	if math.Abs(float64(id.W)-1) > 1e-12 || math.Abs(float64(id.X))+math.Abs(float64(id.Y))+math.Abs(float64(id.Z)) > 1e-12 {
+		t.Errorf("Expected identity, got %v", id)
+	}
+	if axis, theta := geom.QuatIdentity[float64]().AxisAngle(); theta != 0 || axis.Data[0] != 1 {
+		t.Errorf("Expected angle 0 about the x-axis, got %g about %v", theta, axis.Data)
+	}
+
+	// Test case 5: Panic on normalizing a zero quaternion
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Normalize to panic on a zero quaternion, but it did not")
+		}
+	}()
+	geom.Quat[float64]{}.Normalize()
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestSlerp(t *testing.T) {
+	start := time.Now()
+
+	q := geom.QuatIdentity[float64]()
+	p := geom.QuatFromAxisAngle(vector(0, 0, 1), 2)
+
+	// Test case 1: Endpoints and constant angular velocity
+	for _, tt := range []float64{0, 0.25, 0.5, 1} {
+		got := geom.Slerp(q, p, tt)
+		want := geom.QuatFromAxisAngle(vector(0, 0, 1), 2*tt)
+		if math.Abs(got.Dot(want)-1) > 1e-12 {
+			t.Errorf("Slerp at t=%g = %v, want %v", tt, got, want)
+		}
+	}
+
+	// Test case 2: The shortest arc is taken when the quaternions are in opposite hemispheres
+	got := geom.Slerp(q, p.Scale(-1), 0.5)
+	if math.Abs(math.Abs(got.Dot(geom.QuatFromAxisAngle(vector(0, 0, 1), 1)))-1) > 1e-12 {
+		t.Errorf("Expected slerp to take the shortest arc, got %v", got)
+	}
+
+	// Test case 3: Nearly identical rotations stay normalized
+	near := geom.QuatFromAxisAngle(vector(1, 0, 0), 1e-9)
+	if got := geom.Slerp(q, near, 0.5); math.Abs(got.Norm()-1) > 1e-12 {
+		t.Errorf("Expected a unit quaternion, got %v", got)
+	}
+
+	// Test case 4: Float32 quaternions
+	x32 := &mat.Mat[float32]{M: 3, N: 1, Data: []float32{1, 0, 0}}
+	q32 := geom.Slerp(geom.QuatIdentity[float32](), geom.QuatFromAxisAngle(x32, 1), 0.5)
+	mattest.AssertEqual(t, q32.Matrix(), geom.RotationX[float32](0.5), mattest.Tolerance{Abs: 1e-6})
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
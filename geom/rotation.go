@@ -0,0 +1,223 @@
+// Package geom provides 2D and 3D rotations, quaternions and 4x4 homogeneous transforms
+// built on mat. Points and directions are 3-element vectors, either row or column
+// matrices, and results are column matrices. Matrices act on column vectors from the
+// left. Angles are in radians, and positive angles rotate counterclockwise when looking
+// from the tip of the axis towards the origin (the right-hand rule).
+//
+// Invalid shapes and degenerate inputs such as zero-length axes cause a panic, like the
+// vector functions in mat.
+package geom
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/number"
+)
+
+// Rotation2D returns the 2x2 matrix that rotates 2D vectors by theta.
+func Rotation2D[T number.Float](theta float64) *mat.Mat[T] {
+	s, c := math.Sincos(theta)
+	return fromFloat64[T](2, 2, []float64{
+		c, -s,
+		s, c,
+	})
+}
+
+// RotationX returns the 3x3 matrix that rotates by theta about the x-axis.
+func RotationX[T number.Float](theta float64) *mat.Mat[T] {
+	s, c := math.Sincos(theta)
+	return fromFloat64[T](3, 3, []float64{
+		1, 0, 0,
+		0, c, -s,
+		0, s, c,
+	})
+}
+
+// RotationY returns the 3x3 matrix that rotates by theta about the y-axis.
+func RotationY[T number.Float](theta float64) *mat.Mat[T] {
+	s, c := math.Sincos(theta)
+	return fromFloat64[T](3, 3, []float64{
+		c, 0, s,
+		0, 1, 0,
+		-s, 0, c,
+	})
+}
+
+// RotationZ returns the 3x3 matrix that rotates by theta about the z-axis.
+func RotationZ[T number.Float](theta float64) *mat.Mat[T] {
+	s, c := math.Sincos(theta)
+	return fromFloat64[T](3, 3, []float64{
+		c, -s, 0,
+		s, c, 0,
+		0, 0, 1,
+	})
+}
+
+// AxisAngle returns the 3x3 matrix that rotates by theta about axis, using Rodrigues'
+// formula. The axis doesn't need to be normalized. Panics if axis has length 0.
+func AxisAngle[T number.Float](axis *mat.Mat[T], theta float64) *mat.Mat[T] {
+	u := vec3("an axis-angle rotation", mat.Normalize(axis))
+	s, c := math.Sincos(theta)
+	t := 1 - c
+	x, y, z := u[0], u[1], u[2]
+
+	return fromFloat64[T](3, 3, []float64{
+		c + x*x*t, x*y*t - z*s, x*z*t + y*s,
+		y*x*t + z*s, c + y*y*t, y*z*t - x*s,
+		z*x*t - y*s, z*y*t + x*s, c + z*z*t,
+	})
+}
+
+// ToAxisAngle returns the unit axis and the angle in [0, π] of the rotation matrix r.
+// The axis of a rotation by 0 is arbitrary, and the x-axis is returned.
+// Panics if r is not 3x3.
+func ToAxisAngle[T number.Float](r *mat.Mat[T]) (*mat.Mat[T], float64) {
+	return QuatFromMatrix(r).AxisAngle()
+}
+
+// EulerOrder is the order of the axes of an Euler angle rotation.
+type EulerOrder int
+
+// The Tait-Bryan orders, which rotate about each of the three axes once. ZYX is the
+// yaw, pitch and roll convention common in aerospace and robotics.
+const (
+	XYZ EulerOrder = iota
+	XZY
+	YXZ
+	YZX
+	ZXY
+	ZYX
+)
+
+// eulerAxes are the indices of the axes of each order.
+var eulerAxes = [...][3]int{
+	XYZ: {0, 1, 2},
+	XZY: {0, 2, 1},
+	YXZ: {1, 0, 2},
+	YZX: {1, 2, 0},
+	ZXY: {2, 0, 1},
+	ZYX: {2, 1, 0},
+}
+
+func (o EulerOrder) String() string {
+	if o < 0 || int(o) >= len(eulerAxes) {
+		return fmt.Sprintf("EulerOrder(%d)", int(o))
+	}
+
+	name := make([]byte, 3)
+	for i, axis := range eulerAxes[o] {
+		name[i] = "XYZ"[axis]
+	}
+	return string(name)
+}
+
+// axes returns the indices of the axes of o and the sign of the permutation,
+// 1 for the cyclic orders XYZ, YZX and ZXY and -1 for the others.
+func (o EulerOrder) axes() (i, j, k int, sign float64) {
+	if o < 0 || int(o) >= len(eulerAxes) {
+		panic(fmt.Sprintf("geom: invalid Euler order %d", int(o)))
+	}
+
+	ax := eulerAxes[o]
+	sign = 1
+	if (ax[1]-ax[0]+3)%3 != 1 {
+		sign = -1
+	}
+	return ax[0], ax[1], ax[2], sign
+}
+
+// Euler returns the rotation by a about the first axis of order, then b about the
+// rotated second axis and c about the twice rotated third axis. For ZYX this is
+// yaw a, pitch b and roll c, and the result is RotationZ(a) RotationY(b) RotationX(c).
+// Rotations about the fixed axes in the opposite order give the same matrix, so
+// Euler(XYZ, a, b, c) also rotates by c about the fixed z-axis, then b about y and a about x.
+func Euler[T number.Float](order EulerOrder, a, b, c float64) *mat.Mat[T] {
+	i, j, k, _ := order.axes()
+	r := rotationAbout(i, a)
+	r = mul3(r, rotationAbout(j, b))
+	r = mul3(r, rotationAbout(k, c))
+	return fromFloat64[T](3, 3, r[:])
+}
+
+// ToEuler returns the angles of the rotation matrix r in the given order, such that
+// Euler(order, a, b, c) gives r back. a and c are in [-π, π] and b in [-π/2, π/2].
+// At b = ±π/2 (gimbal lock) only a combination of a and c is determined, and c is set to 0.
+// Panics if r is not 3x3.
+func ToEuler[T number.Float](r *mat.Mat[T], order EulerOrder) (a, b, c float64) {
+	i, j, k, sign := order.axes()
+	m := mat3("Euler angles", r)
+	at := func(row, col int) float64 { return m[row*3+col] }
+
+	sb := sign * at(i, k)
+	b = math.Asin(math.Max(-1, math.Min(1, sb)))
+	if math.Abs(sb) < 1-1e-9 {
+		a = math.Atan2(-sign*at(j, k), at(k, k))
+		c = math.Atan2(-sign*at(i, j), at(i, i))
+		return a, b, c
+	}
+
+	// Gimbal lock: the first and last axes line up, so fold all of the rotation about them into a
+	a = math.Atan2(sign*at(k, j), at(j, j))
+	return a, b, 0
+}
+
+// rotationAbout returns the rotation by theta about the x-, y- or z-axis for axis 0, 1 or 2.
+func rotationAbout(axis int, theta float64) [9]float64 {
+	var r [9]float64
+	switch axis {
+	case 0:
+		copy(r[:], RotationX[float64](theta).Data)
+	case 1:
+		copy(r[:], RotationY[float64](theta).Data)
+	default:
+		copy(r[:], RotationZ[float64](theta).Data)
+	}
+	return r
+}
+
+// mul3 multiplies two row-major 3x3 matrices.
+func mul3(a, b [9]float64) [9]float64 {
+	var r [9]float64
+	for i := range 3 {
+		for j := range 3 {
+			r[i*3+j] = a[i*3]*b[j] + a[i*3+1]*b[3+j] + a[i*3+2]*b[6+j]
+		}
+	}
+	return r
+}
+
+// vec3 returns the elements of a 3-element row or column vector as float64.
+func vec3[T number.Float](op string, v *mat.Mat[T]) [3]float64 {
+	if !v.IsVector() || len(v.Data) != 3 {
+		panic(fmt.Sprintf("geom: %s needs a 3-element vector, got a %dx%d matrix", op, v.M, v.N))
+	}
+	return [3]float64{float64(v.Data[0]), float64(v.Data[1]), float64(v.Data[2])}
+}
+
+// mat3 returns the elements of a 3x3 matrix as float64.
+func mat3[T number.Float](op string, m *mat.Mat[T]) [9]float64 {
+	if m.M != 3 || m.N != 3 {
+		panic(fmt.Sprintf("geom: %s needs a 3x3 rotation matrix, got %dx%d", op, m.M, m.N))
+	}
+	var r [9]float64
+	for i, v := range m.Data {
+		r[i] = float64(v)
+	}
+	return r
+}
+
+// fromFloat64 returns an m x n matrix of data converted to T.
+func fromFloat64[T number.Float](m, n int, data []float64) *mat.Mat[T] {
+	d := make([]T, len(data))
+	for i, v := range data {
+		d[i] = T(v)
+	}
+	return &mat.Mat[T]{M: m, N: n, Data: d}
+}
+
+// column returns a 3x1 column vector.
+func column[T number.Float](x, y, z float64) *mat.Mat[T] {
+	return fromFloat64[T](3, 1, []float64{x, y, z})
+}
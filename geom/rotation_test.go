@@ -0,0 +1,119 @@
+package geom_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/geom"
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+var tol = mattest.Tolerance{Abs: 1e-12}
+
+func vector(x, y, z float64) *mat.Mat[float64] {
+	return &mat.Mat[float64]{M: 3, N: 1, Data: []float64{x, y, z}}
+}
+
+func dot(t *testing.T, a, b *mat.Mat[float64]) *mat.Mat[float64] {
+	t.Helper()
+	res, err := mat.Dot(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return res
+}
+
+func TestRotations(t *testing.T) {
+	start := time.Now()
+
+	// Test case 1: Quarter turns follow the right-hand rule
+	r2, _ := mat.New([][]float64{{0, -1}, {1, 0}})
+	mattest.AssertEqual(t, geom.Rotation2D[float64](math.Pi/2), r2, tol)
+	mattest.AssertEqual(t, dot(t, geom.RotationX[float64](math.Pi/2), vector(0, 1, 0)), vector(0, 0, 1), tol)
+	mattest.AssertEqual(t, dot(t, geom.RotationY[float64](math.Pi/2), vector(0, 0, 1)), vector(1, 0, 0), tol)
+	mattest.AssertEqual(t, dot(t, geom.RotationZ[float64](math.Pi/2), vector(1, 0, 0)), vector(0, 1, 0), tol)
+
+	// Test case 2: Axis-angle matches the elementary rotations and normalizes the axis
+	mattest.AssertEqual(t, geom.AxisAngle(vector(0, 0, 5), 0.7), geom.RotationZ[float64](0.7), tol)
+	mattest.AssertEqual(t, geom.AxisAngle(vector(-2, 0, 0), 0.7), geom.RotationX[float64](-0.7), tol)
+
+	// Test case 3: A third of a turn about the diagonal cycles the axes
+	mattest.AssertEqual(t, dot(t, geom.AxisAngle(vector(1, 1, 1), 2*math.Pi/3), vector(1, 0, 0)), vector(0, 1, 0), tol)
+
+	// Test case 4: Axis and angle are recovered, with the axis flipped for negative angles
+	axis, theta := geom.ToAxisAngle(geom.AxisAngle(vector(1, 2, 2), -1.2))
+	mattest.AssertEqual(t, axis, vector(-1.0/3, -2.0/3, -2.0/3), tol)
+	if math.Abs(theta-1.2) > 1e-12 {
+		t.Errorf("Expected angle 1.2, got %g", theta)
+	}
+	axis, theta = geom.ToAxisAngle(geom.AxisAngle(vector(0, 1, 0), math.Pi))
+	mattest.AssertEqual(t, axis, vector(0, 1, 0), tol)
+	if math.Abs(theta-math.Pi) > 1e-12 {
+		t.Errorf("Expected angle π, got %g", theta)
+	}
+
+	// Test case 5: Float32 rotations
+	r32 := geom.RotationZ[float32](math.Pi / 2)
+	want32, _ := mat.New([][]float32{{0, -1, 0}, {1, 0, 0}, {0, 0, 1}})
+	mattest.AssertEqual(t, r32, want32, mattest.Tolerance{Abs: 1e-7})
+
+	// Test case 6: Panic on a zero axis
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected AxisAngle to panic on a zero axis, but it did not")
+		}
+	}()
+	geom.AxisAngle(vector(0, 0, 0), 1)
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestEuler(t *testing.T) {
+	start := time.Now()
+
+	orders := []geom.EulerOrder{geom.XYZ, geom.XZY, geom.YXZ, geom.YZX, geom.ZXY, geom.ZYX}
+	elementary := map[byte]func(float64) *mat.Mat[float64]{
+		'X': geom.RotationX[float64],
+		'Y': geom.RotationY[float64],
+		'Z': geom.RotationZ[float64],
+	}
+
+	for _, order := range orders {
+		name := order.String()
+		a, b, c := 0.3, -0.8, 2.1
+
+		// Test case 1: Intrinsic rotations multiply from left to right in the named order
+		want := dot(t, dot(t, elementary[name[0]](a), elementary[name[1]](b)), elementary[name[2]](c))
+		r := geom.Euler[float64](order, a, b, c)
+		if !mattest.AssertEqual(t, r, want, tol) {
+			t.Errorf("Wrong rotation for order %s", name)
+		}
+
+		// Test case 2: Angles are recovered
+		ga, gb, gc := geom.ToEuler(r, order)
+		if math.Abs(ga-a) > 1e-12 || math.Abs(gb-b) > 1e-12 || math.Abs(gc-c) > 1e-12 {
+			t.Errorf("ToEuler(%s) = (%g, %g, %g), want (%g, %g, %g)", name, ga, gb, gc, a, b, c)
+		}
+
+		// Test case 3: At gimbal lock the angles still give back the same rotation
+		for _, b := range []float64{math.Pi / 2, -math.Pi / 2} {
+			r := geom.Euler[float64](order, 0.4, b, -0.9)
+			ga, gb, gc := geom.ToEuler(r, order)
+			if gc != 0 {
+				t.Errorf("Expected the last angle to be 0 at gimbal lock for %s, got %g", name, gc)
+			}
+			if !mattest.AssertEqual(t, geom.Euler[float64](order, ga, gb, gc), r, mattest.Tolerance{Abs: 1e-9}) {
+				t.Errorf("Wrong rotation at gimbal lock for order %s", name)
+			}
+		}
+	}
+
+	if s := geom.EulerOrder(42).String(); s != "EulerOrder(42)" {
+		t.Errorf("Expected EulerOrder(42), got %s", s)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
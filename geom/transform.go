@@ -0,0 +1,176 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/number"
+)
+
+// Homogeneous transforms are 4x4 matrices acting on points (x, y, z, 1) and directions
+// (x, y, z, 0). They are composed with mat.Dot, with the transform applied first on the right.
+
+// Identity4 returns the 4x4 identity transform.
+func Identity4[T number.Float]() *mat.Mat[T] {
+	return Homogeneous[T](nil, nil)
+}
+
+// Translate returns the transform that moves points by (x, y, z).
+func Translate[T number.Float](x, y, z T) *mat.Mat[T] {
+	h := Identity4[T]()
+	h.Data[3], h.Data[7], h.Data[11] = x, y, z
+	return h
+}
+
+// Scale returns the transform that scales along the axes by x, y and z.
+func Scale[T number.Float](x, y, z T) *mat.Mat[T] {
+	h := Identity4[T]()
+	h.Data[0], h.Data[5], h.Data[10] = x, y, z
+	return h
+}
+
+// Homogeneous returns the transform that rotates by the 3x3 matrix r and then moves
+// by the 3-element vector t. A nil r is the identity and a nil t is no translation.
+// Panics if r is not 3x3 or t doesn't have 3 elements.
+func Homogeneous[T number.Float](r, t *mat.Mat[T]) *mat.Mat[T] {
+	h := &mat.Mat[T]{M: 4, N: 4, Data: make([]T, 16)}
+	h.Data[0], h.Data[5], h.Data[10], h.Data[15] = 1, 1, 1, 1
+
+	if r != nil {
+		m := mat3("a homogeneous transform", r)
+		for i := range 3 {
+			for j := range 3 {
+				h.Data[i*4+j] = T(m[i*3+j])
+			}
+		}
+	}
+	if t != nil {
+		v := vec3("a homogeneous transform", t)
+		for i := range 3 {
+			h.Data[i*4+3] = T(v[i])
+		}
+	}
+	return h
+}
+
+// FromQuat returns the transform that rotates by q and then moves by t, see Homogeneous.
+func FromQuat[T number.Float](q Quat[T], t *mat.Mat[T]) *mat.Mat[T] {
+	return Homogeneous(q.Matrix(), t)
+}
+
+// Decompose splits a rigid transform into its 3x3 rotation and its translation as a
+// column vector, the inverse of Homogeneous. A transform that also scales returns the
+// scaled rotation. Panics if h is not 4x4.
+func Decompose[T number.Float](h *mat.Mat[T]) (r, t *mat.Mat[T]) {
+	checkTransform("decompose", h)
+	r = mat.Sub(h, 0, 3, 0, 3)
+	t = mat.Sub(h, 0, 3, 3, 4)
+	return r, t
+}
+
+// RigidInverse returns the inverse of a transform made of a rotation and a translation,
+// which is the transposed rotation followed by the rotated opposite translation. It is
+// cheaper and more accurate than a general inverse, but is wrong for transforms that
+// scale or project. Panics if h is not 4x4.
+func RigidInverse[T number.Float](h *mat.Mat[T]) *mat.Mat[T] {
+	r, t := Decompose(h)
+	rt := mat.Transpose(r)
+	// The product of a 3x3 and 3x1 matrix can't fail
+	rtt, _ := mat.Dot(rt, t)
+	return Homogeneous(rt, mat.Scale(rtt, -1))
+}
+
+// TransformPoint applies h to the point p and returns a column vector. The result is
+// divided by its w component, so perspective transforms give normalized device
+// coordinates. Panics if h is not 4x4, p doesn't have 3 elements or w is 0.
+func TransformPoint[T number.Float](h, p *mat.Mat[T]) *mat.Mat[T] {
+	checkTransform("transform a point", h)
+	v := vec3("a point transform", p)
+
+	var res [4]float64
+	for i := range res {
+		row := h.Data[i*4 : i*4+4]
+		res[i] = float64(row[0])*v[0] + float64(row[1])*v[1] + float64(row[2])*v[2] + float64(row[3])
+	}
+	if res[3] == 0 {
+		panic("geom: point is transformed to infinity (w = 0)")
+	}
+	return column[T](res[0]/res[3], res[1]/res[3], res[2]/res[3])
+}
+
+// TransformVector applies h to the direction v, which rotates and scales it but doesn't
+// move it, and returns a column vector. Panics if h is not 4x4 or v doesn't have 3 elements.
+func TransformVector[T number.Float](h, v *mat.Mat[T]) *mat.Mat[T] {
+	checkTransform("transform a vector", h)
+	u := vec3("a vector transform", v)
+
+	var res [3]float64
+	for i := range res {
+		row := h.Data[i*4 : i*4+3]
+		res[i] = float64(row[0])*u[0] + float64(row[1])*u[1] + float64(row[2])*u[2]
+	}
+	return column[T](res[0], res[1], res[2])
+}
+
+// LookAt returns the view transform of a camera at eye looking at target, with up
+// pointing roughly upwards on the screen. It follows the OpenGL convention: the camera
+// looks down its negative z-axis with x to the right and y up. Panics if eye and target
+// are the same point or up is parallel to the viewing direction.
+func LookAt[T number.Float](eye, target, up *mat.Mat[T]) *mat.Mat[T] {
+	e := asColumn("a look-at transform", eye)
+	f := mat.Subtract(asColumn("a look-at transform", target), e)
+	u := asColumn("a look-at transform", up)
+	if f.Length() == 0 {
+		panic("geom: cannot look at a target at the eye position")
+	}
+	f = mat.Normalize(f)
+
+	side := mat.CrossProduct(f, u)
+	if side.Length() <= 1e-6*u.Length() {
+		panic("geom: up vector is parallel to the viewing direction")
+	}
+	side = mat.Normalize(side)
+	u = mat.CrossProduct(side, f)
+
+	view := Homogeneous[T](nil, nil)
+	for j := range 3 {
+		view.Data[j] = side.Data[j]
+		view.Data[4+j] = u.Data[j]
+		view.Data[8+j] = -f.Data[j]
+	}
+	view.Data[3] = -mat.VectorDot(side, e)
+	view.Data[7] = -mat.VectorDot(u, e)
+	view.Data[11] = mat.VectorDot(f, e)
+	return view
+}
+
+// Perspective returns the OpenGL style projection of a camera with the vertical field of
+// view fovy in radians and width to height ratio aspect. Points in view space between the
+// near and far clipping planes are mapped to z in [-1, 1] after TransformPoint divides by w.
+// Panics unless 0 < fovy < π, aspect > 0 and 0 < near < far.
+func Perspective[T number.Float](fovy, aspect, near, far float64) *mat.Mat[T] {
+	if !(fovy > 0 && fovy < math.Pi) || !(aspect > 0) || !(near > 0 && far > near) {
+		panic(fmt.Sprintf("geom: invalid perspective fovy=%g aspect=%g near=%g far=%g", fovy, aspect, near, far))
+	}
+
+	f := 1 / math.Tan(fovy/2)
+	return fromFloat64[T](4, 4, []float64{
+		f / aspect, 0, 0, 0,
+		0, f, 0, 0,
+		0, 0, (far + near) / (near - far), 2 * far * near / (near - far),
+		0, 0, -1, 0,
+	})
+}
+
+func checkTransform[T number.Float](op string, h *mat.Mat[T]) {
+	if h.M != 4 || h.N != 4 {
+		panic(fmt.Sprintf("geom: cannot %s with a %dx%d matrix, transforms are 4x4", op, h.M, h.N))
+	}
+}
+
+// asColumn returns a 3-element row or column vector as a column vector.
+func asColumn[T number.Float](op string, v *mat.Mat[T]) *mat.Mat[T] {
+	u := vec3(op, v)
+	return column[T](u[0], u[1], u[2])
+}
@@ -0,0 +1,91 @@
+package geom_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/geom"
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+func TestHomogeneous(t *testing.T) {
+	start := time.Now()
+
+	// Test case 1: Translation moves points but not directions
+	tr := geom.Translate(1.0, 2, 3)
+	mattest.AssertEqual(t, geom.TransformPoint(tr, vector(1, 1, 1)), vector(2, 3, 4), tol)
+	mattest.AssertEqual(t, geom.TransformVector(tr, vector(1, 1, 1)), vector(1, 1, 1), tol)
+
+	// Test case 2: Scaling, composed with translation from right to left
+	sc := geom.Scale(2.0, 3, 4)
+	mattest.AssertEqual(t, geom.TransformPoint(dot(t, tr, sc), vector(1, 1, 1)), vector(3, 5, 7), tol)
+
+	// Test case 3: Rotation and translation round trip through Decompose
+	r := geom.Euler[float64](geom.ZYX, 0.3, -0.2, 1.4)
+	h := geom.Homogeneous(r, &mat.Mat[float64]{M: 1, N: 3, Data: []float64{5, -1, 2}})
+	gotR, gotT := geom.Decompose(h)
+	mattest.AssertEqual(t, gotR, r, tol)
+	mattest.AssertEqual(t, gotT, vector(5, -1, 2), tol)
+	mattest.AssertEqual(t, geom.FromQuat(geom.QuatFromMatrix(r), gotT), h, tol)
+
+	// Test case 4: The rigid inverse undoes the transform
+	mattest.AssertEqual(t, dot(t, geom.RigidInverse(h), h), geom.Identity4[float64](), tol)
+	p := vector(0.5, 7, -3)
+	mattest.AssertEqual(t, geom.TransformPoint(geom.RigidInverse(h), geom.TransformPoint(h, p)), p, tol)
+
+	// Test case 5: Panic on a matrix that isn't 4x4
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected TransformPoint to panic on a 3x3 matrix, but it did not")
+		}
+	}()
+	geom.TransformPoint(r, p)
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestCamera(t *testing.T) {
+	start := time.Now()
+
+	// Test case 1: The view looks from eye towards target along the negative z-axis
+	eye, target := vector(0, 0, 5), vector(0, 0, 0)
+	view := geom.LookAt(eye, target, vector(0, 1, 0))
+	mattest.AssertEqual(t, view, geom.Translate(0.0, 0, -5), tol)
+
+	eye, target = vector(3, 4, 2), vector(-1, 0, 2)
+	view = geom.LookAt(eye, target, vector(0, 0, 1))
+	mattest.AssertEqual(t, geom.TransformPoint(view, eye), vector(0, 0, 0), tol)
+	mattest.AssertEqual(t, geom.TransformPoint(view, target), vector(0, 0, -math.Sqrt(32)), tol)
+	mattest.AssertEqual(t, geom.TransformVector(view, vector(0, 0, 1)), vector(0, 1, 0), tol)
+
+	// Test case 2: The projection maps the clipping planes to -1 and 1
+	proj := geom.Perspective[float64](math.Pi/2, 2, 1, 10)
+	mattest.AssertEqual(t, geom.TransformPoint(proj, vector(0, 0, -1)), vector(0, 0, -1), tol)
+	mattest.AssertEqual(t, geom.TransformPoint(proj, vector(0, 0, -10)), vector(0, 0, 1), tol)
+
+	// Test case 3: Corners of the near plane map to the corners of the view volume
+	mattest.AssertEqual(t, geom.TransformPoint(proj, vector(2, 1, -1)), vector(1, 1, -1), tol)
+
+	// Test case 4: Panics on degenerate cameras
+	panics := map[string]func(){
+		"same eye and target": func() { geom.LookAt(eye, eye, vector(0, 0, 1)) },
+		"parallel up":         func() { geom.LookAt(eye, target, vector(1, 1, 0)) },
+		"near after far":      func() { geom.Perspective[float64](1, 1, 10, 1) },
+		"zero field of view":  func() { geom.Perspective[float64](0, 1, 1, 10) },
+	}
+	for name, fn := range panics {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected panic for %s, but there was none", name)
+				}
+			}()
+			fn()
+		}()
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
@@ -1,3 +1,5 @@
 module github.com/lattots/gonum
 
 go 1.23.4
+
+require golang.org/x/sys v0.30.0
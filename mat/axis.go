@@ -0,0 +1,32 @@
+package mat
+
+// Axis selects the direction along which an operation is applied.
+type Axis int
+
+const (
+	// AxisAll applies the operation to every element as if the matrix were flat.
+	AxisAll Axis = iota
+	// AxisRows applies the operation down the rows, once for each column.
+	// This is the direction SumRows collapses.
+	AxisRows
+	// AxisCols applies the operation across the columns, once for each row.
+	// This is the direction SumColumns collapses.
+	AxisCols
+)
+
+func (a Axis) String() string {
+	switch a {
+	case AxisAll:
+		return "all"
+	case AxisRows:
+		return "rows"
+	case AxisCols:
+		return "columns"
+	default:
+		return "invalid axis"
+	}
+}
+
+func (a Axis) valid() bool {
+	return a == AxisAll || a == AxisRows || a == AxisCols
+}
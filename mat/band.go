@@ -0,0 +1,288 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lattots/gonum/number"
+)
+
+// BandDense is an n x n band matrix with KL subdiagonals and KU superdiagonals, such as
+// the systems from finite difference discretizations. Each row stores the KL+KU+1
+// elements of the band, so Data holds n(KL+KU+1) elements. Element (i, j) of the band
+// is at Data[i*(KL+KU+1)+j-i+KL] with 0-based indices; the slots that fall outside the
+// matrix in the first and last rows are unused.
+type BandDense[T number.Num] struct {
+	N, KL, KU int
+	Data      []T
+}
+
+// NewBandDense creates an n x n band matrix of zeros with kl subdiagonals and ku
+// superdiagonals.
+func NewBandDense[T number.Num](n, kl, ku int) (*BandDense[T], error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("dimensions of matrices must be above zero")
+	}
+	if kl < 0 || ku < 0 || kl >= n || ku >= n {
+		return nil, fmt.Errorf("bandwidths must be in [0, %d), got %d and %d", n, kl, ku)
+	}
+	return &BandDense[T]{N: n, KL: kl, KU: ku, Data: make([]T, n*(kl+ku+1))}, nil
+}
+
+// BandDenseFrom copies the square matrix m into a band matrix. Returns an error if m
+// is not square, the bandwidths are invalid or m has non-zero elements outside the band.
+func BandDenseFrom[T number.Num](m *Mat[T], kl, ku int) (*BandDense[T], error) {
+	if m.M != m.N {
+		return nil, shapeErrorf("BandDenseFrom", "need a square matrix, got %dx%d", m.M, m.N)
+	}
+	b, err := NewBandDense[T](m.N, kl, ku)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range m.M {
+		for j := range m.N {
+			v := m.Data[i*m.N+j]
+			if b.inBand(i, j) {
+				b.Data[b.index(i, j)] = v
+			} else if v != 0 {
+				return nil, fmt.Errorf("matrix is not banded: element (%d, %d) is %v", i+1, j+1, v)
+			}
+		}
+	}
+	return b, nil
+}
+
+// Dims returns the number of rows and columns of b.
+func (b *BandDense[T]) Dims() (rows, cols int) {
+	return b.N, b.N
+}
+
+// At returns the element at the 1-based row i and column j, which is zero outside the
+// band. Panics if the index is out of range.
+func (b *BandDense[T]) At(i, j int) T {
+	checkSquareIndex(i, j, b.N)
+	if !b.inBand(i-1, j-1) {
+		return 0
+	}
+	return b.Data[b.index(i-1, j-1)]
+}
+
+// SetBand sets the element at the 1-based row i and column j. Panics if the index is
+// out of range or outside the band.
+func (b *BandDense[T]) SetBand(i, j int, v T) {
+	checkSquareIndex(i, j, b.N)
+	if !b.inBand(i-1, j-1) {
+		panic(fmt.Sprintf("matrix index error: index (%d, %d) is outside the band", i, j))
+	}
+	b.Data[b.index(i-1, j-1)] = v
+}
+
+// Dense returns a copy of b as a general matrix.
+func (b *BandDense[T]) Dense() *Mat[T] {
+	return toDense[T](b)
+}
+
+func (b *BandDense[T]) String() string {
+	return b.Dense().String()
+}
+
+func (b *BandDense[T]) inBand(i, j int) bool {
+	return j-i >= -b.KL && j-i <= b.KU
+}
+
+func (b *BandDense[T]) index(i, j int) int {
+	return i*(b.KL+b.KU+1) + j - i + b.KL
+}
+
+// SolveTridiag solves a X = b for a tridiagonal matrix with the Thomas algorithm in
+// O(n) operations per column of b. It doesn't pivot, so it is meant for diagonally
+// dominant or symmetric positive definite systems; use FactorBand otherwise. Returns
+// an error if a has more than one subdiagonal or superdiagonal, and ErrSingular if
+// elimination meets a zero pivot.
+func SolveTridiag[T number.Float](a *BandDense[T], b *Mat[T]) (*Mat[T], error) {
+	if a.KL > 1 || a.KU > 1 {
+		return nil, shapeErrorf("SolveTridiag", "need a tridiagonal matrix, got %d subdiagonals and %d superdiagonals", a.KL, a.KU)
+	}
+	if b.M != a.N {
+		return nil, shapeErrorf("SolveTridiag", "right-hand side has %d rows, need %d", b.M, a.N)
+	}
+
+	n, cols := a.N, b.N
+	at := func(i, j int) float64 {
+		if !a.inBand(i, j) {
+			return 0
+		}
+		return float64(a.Data[a.index(i, j)])
+	}
+
+	// Forward sweep: c holds the modified superdiagonal, shared by all columns
+	c := make([]float64, n)
+	denoms := make([]float64, n)
+	for i := range n {
+		denom := at(i, i)
+		if i > 0 {
+			denom -= at(i, i-1) * c[i-1]
+		}
+		if denom == 0 {
+			return nil, ErrSingular
+		}
+		denoms[i] = denom
+		if i < n-1 {
+			c[i] = at(i, i+1) / denom
+		}
+	}
+
+	x := &Mat[T]{M: b.M, N: b.N, Data: make([]T, len(b.Data))}
+	d := make([]float64, n)
+	for j := range cols {
+		for i := range n {
+			d[i] = float64(b.Data[i*cols+j])
+			if i > 0 {
+				d[i] -= at(i, i-1) * d[i-1]
+			}
+			d[i] /= denoms[i]
+		}
+		// Back substitution
+		for i := n - 2; i >= 0; i-- {
+			d[i] -= c[i] * d[i+1]
+		}
+		for i := range n {
+			x.Data[i*cols+j] = T(d[i])
+		}
+	}
+
+	return x, nil
+}
+
+// BandLU is the LU factorization of a band matrix with partial pivoting. Row swaps
+// widen U to KL+KU superdiagonals, but the factorization stays banded, so factorizing
+// takes O(n·KL·(KL+KU)) operations and each solve O(n·(2KL+KU)) per column instead of
+// the O(n³) and O(n²) of a dense matrix. A factorization can be reused for many
+// right-hand sides, as in implicit time stepping.
+type BandLU[T number.Float] struct {
+	n, kl, ku int
+	// u holds the rows of U, each with the diagonal and KL+KU superdiagonals.
+	u []float64
+	// l holds the KL multipliers of each elimination step.
+	l   []float64
+	piv []int
+}
+
+// FactorBand calculates the LU factorization of a with partial pivoting. Returns
+// ErrSingular if a is singular.
+func FactorBand[T number.Float](a *BandDense[T]) (*BandLU[T], error) {
+	n, kl, ku := a.N, a.KL, a.KU
+	// Rows are stored from column i-kl to i+kl+ku to make room for the fill-in from swaps
+	w := 2*kl + ku + 1
+	work := make([]float64, n*w)
+	for i := range n {
+		for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+			work[i*w+j-i+kl] = float64(a.Data[a.index(i, j)])
+		}
+	}
+	at := func(i, j int) *float64 { return &work[i*w+j-i+kl] }
+
+	f := &BandLU[T]{n: n, kl: kl, ku: ku, l: make([]float64, n*kl), piv: make([]int, n)}
+	for k := range n {
+		last := min(n-1, k+kl)
+		p := k
+		for i := k + 1; i <= last; i++ {
+			if math.Abs(*at(i, k)) > math.Abs(*at(p, k)) {
+				p = i
+			}
+		}
+		f.piv[k] = p
+		if *at(p, k) == 0 {
+			return nil, ErrSingular
+		}
+
+		lastCol := min(n-1, k+kl+ku)
+		if p != k {
+			for j := k; j <= lastCol; j++ {
+				*at(k, j), *at(p, j) = *at(p, j), *at(k, j)
+			}
+		}
+
+		pivot := *at(k, k)
+		for i := k + 1; i <= last; i++ {
+			l := *at(i, k) / pivot
+			f.l[k*kl+i-k-1] = l
+			if l == 0 {
+				continue
+			}
+			for j := k + 1; j <= lastCol; j++ {
+				*at(i, j) -= l * *at(k, j)
+			}
+		}
+	}
+
+	// Keep only U, from the diagonal to KL+KU superdiagonals
+	uw := kl + ku + 1
+	f.u = make([]float64, n*uw)
+	for i := range n {
+		copy(f.u[i*uw:(i+1)*uw], work[i*w+kl:(i+1)*w])
+	}
+	return f, nil
+}
+
+// Solve solves a X = b with the factorization of a.
+func (f *BandLU[T]) Solve(b *Mat[T]) (*Mat[T], error) {
+	if b.M != f.n {
+		return nil, shapeErrorf("BandLU.Solve", "right-hand side has %d rows, need %d", b.M, f.n)
+	}
+
+	n, kl, cols := f.n, f.kl, b.N
+	uw := f.kl + f.ku + 1
+	x := convert[float64](b)
+	row := func(i int) []float64 { return x.Data[i*cols : (i+1)*cols] }
+
+	// Apply the row swaps and eliminations in the order they were made
+	for k := range n {
+		if p := f.piv[k]; p != k {
+			rk, rp := row(k), row(p)
+			for j := range rk {
+				rk[j], rp[j] = rp[j], rk[j]
+			}
+		}
+		for i := k + 1; i <= min(n-1, k+kl); i++ {
+			if l := f.l[k*kl+i-k-1]; l != 0 {
+				ri, rk := row(i), row(k)
+				for j := range ri {
+					ri[j] -= l * rk[j]
+				}
+			}
+		}
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		ri := row(i)
+		for k := i + 1; k <= min(n-1, i+uw-1); k++ {
+			if u := f.u[i*uw+k-i]; u != 0 {
+				rk := row(k)
+				for j := range ri {
+					ri[j] -= u * rk[j]
+				}
+			}
+		}
+		d := f.u[i*uw]
+		for j := range ri {
+			ri[j] /= d
+		}
+	}
+
+	return convert[T](x), nil
+}
+
+// SolveBand solves a X = b with a banded LU factorization. Returns ErrSingular if a
+// is singular. Use FactorBand to solve several systems with the same matrix.
+func SolveBand[T number.Float](a *BandDense[T], b *Mat[T]) (*Mat[T], error) {
+	if b.M != a.N {
+		return nil, shapeErrorf("SolveBand", "right-hand side has %d rows, need %d", b.M, a.N)
+	}
+	f, err := FactorBand(a)
+	if err != nil {
+		return nil, err
+	}
+	return f.Solve(b)
+}
@@ -0,0 +1,152 @@
+package mat
+
+import (
+	"fmt"
+
+	"github.com/lattots/gonum/number"
+)
+
+// BroadcastShape returns the shape that results from combining m1 and m2 element-wise.
+// Two dimensions are compatible when they are equal or when one of them is 1, in which
+// case the matrix is stretched along that dimension. For example a 1xN row vector
+// broadcasts against an MxN matrix to MxN, an Mx1 column vector and a 1xN row vector
+// broadcast to MxN, and a 1x1 matrix broadcasts against anything.
+func BroadcastShape[T number.Num](m1, m2 *Mat[T]) (rows, cols int, err error) {
+	return broadcastShape("BroadcastShape", m1, m2)
+}
+
+func broadcastShape[T number.Num](op string, m1, m2 *Mat[T]) (int, int, error) {
+	rows, ok := broadcastDim(m1.M, m2.M)
+	if !ok {
+		return 0, 0, shapeErrorf(op, "cannot broadcast %dx%d and %dx%d", m1.M, m1.N, m2.M, m2.N)
+	}
+	cols, ok := broadcastDim(m1.N, m2.N)
+	if !ok {
+		return 0, 0, shapeErrorf(op, "cannot broadcast %dx%d and %dx%d", m1.M, m1.N, m2.M, m2.N)
+	}
+	return rows, cols, nil
+}
+
+func broadcastDim(d1, d2 int) (int, bool) {
+	switch {
+	case d1 == d2:
+		return d1, true
+	case d1 == 1:
+		return d2, true
+	case d2 == 1:
+		return d1, true
+	default:
+		return 0, false
+	}
+}
+
+// broadcast applies fn element-wise to m1 and m2 after broadcasting them to a common shape.
+func broadcast[T number.Num](op string, m1, m2 *Mat[T], fn func(a, b T) T) (*Mat[T], error) {
+	rows, cols, err := broadcastShape(op, m1, m2)
+	if err != nil {
+		return nil, err
+	}
+
+	// A stride of 0 repeats the same row or column for every step along a broadcast dimension.
+	rowStride1, colStride1 := broadcastStrides(m1)
+	rowStride2, colStride2 := broadcastStrides(m2)
+
+	data := make([]T, rows*cols)
+	for r := 0; r < rows; r++ {
+		idx1 := r * rowStride1
+		idx2 := r * rowStride2
+		out := data[r*cols : (r+1)*cols]
+		for c := range out {
+			out[c] = fn(m1.Data[idx1], m2.Data[idx2])
+			idx1 += colStride1
+			idx2 += colStride2
+		}
+	}
+
+	return &Mat[T]{
+		M:    rows,
+		N:    cols,
+		Data: data,
+	}, nil
+}
+
+func broadcastStrides[T number.Num](m *Mat[T]) (rowStride, colStride int) {
+	if m.M > 1 {
+		rowStride = m.N
+	}
+	if m.N > 1 {
+		colStride = 1
+	}
+	return rowStride, colStride
+}
+
+// Div divides m1 by m2 element-wise, broadcasting compatible shapes.
+// Integer division by zero returns an error instead of panicking.
+func Div[T number.Num](m1, m2 *Mat[T]) (*Mat[T], error) {
+	if !isFloat[T]() {
+		for _, val := range m2.Data {
+			if val == 0 {
+				return nil, fmt.Errorf("matrix math error: integer division by zero")
+			}
+		}
+	}
+
+	return broadcast("Div", m1, m2, func(a, b T) T { return a / b })
+}
+
+// Map2 applies fn to each pair of elements of m1 and m2, broadcasting compatible shapes.
+func Map2[T number.Num](m1, m2 *Mat[T], fn func(a, b T) T) (*Mat[T], error) {
+	return broadcast("Map2", m1, m2, fn)
+}
+
+// Equal compares m1 and m2 element-wise, broadcasting compatible shapes.
+// The result holds 1 where the elements are equal and 0 elsewhere.
+func Equal[T number.Num](m1, m2 *Mat[T]) (*Mat[T], error) {
+	return broadcast("Equal", m1, m2, func(a, b T) T { return boolToNum[T](a == b) })
+}
+
+// NotEqual compares m1 and m2 element-wise, broadcasting compatible shapes.
+// The result holds 1 where the elements differ and 0 elsewhere.
+func NotEqual[T number.Num](m1, m2 *Mat[T]) (*Mat[T], error) {
+	return broadcast("NotEqual", m1, m2, func(a, b T) T { return boolToNum[T](a != b) })
+}
+
+// Greater compares m1 and m2 element-wise, broadcasting compatible shapes.
+// The result holds 1 where m1 is greater than m2 and 0 elsewhere.
+func Greater[T number.Num](m1, m2 *Mat[T]) (*Mat[T], error) {
+	return broadcast("Greater", m1, m2, func(a, b T) T { return boolToNum[T](a > b) })
+}
+
+// GreaterEqual compares m1 and m2 element-wise, broadcasting compatible shapes.
+// The result holds 1 where m1 is greater than or equal to m2 and 0 elsewhere.
+func GreaterEqual[T number.Num](m1, m2 *Mat[T]) (*Mat[T], error) {
+	return broadcast("GreaterEqual", m1, m2, func(a, b T) T { return boolToNum[T](a >= b) })
+}
+
+// Less compares m1 and m2 element-wise, broadcasting compatible shapes.
+// The result holds 1 where m1 is less than m2 and 0 elsewhere.
+func Less[T number.Num](m1, m2 *Mat[T]) (*Mat[T], error) {
+	return broadcast("Less", m1, m2, func(a, b T) T { return boolToNum[T](a < b) })
+}
+
+// LessEqual compares m1 and m2 element-wise, broadcasting compatible shapes.
+// The result holds 1 where m1 is less than or equal to m2 and 0 elsewhere.
+func LessEqual[T number.Num](m1, m2 *Mat[T]) (*Mat[T], error) {
+	return broadcast("LessEqual", m1, m2, func(a, b T) T { return boolToNum[T](a <= b) })
+}
+
+func boolToNum[T number.Num](b bool) T {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func isFloat[T number.Num]() bool {
+	switch any(*new(T)).(type) {
+	case float32, float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,148 @@
+package mat
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/lattots/gonum/number"
+)
+
+// CSR is an m x n sparse matrix in compressed sparse row format. Only the non-zero
+// elements are stored: the 0-based column indices of row i are ColIdx[RowPtr[i]:RowPtr[i+1]]
+// in increasing order, and Data holds the matching values. RowPtr has M+1 elements.
+type CSR[T number.Num] struct {
+	M      int
+	N      int
+	RowPtr []int
+	ColIdx []int
+	Data   []T
+}
+
+// Dims returns the number of rows and columns of c.
+func (c *CSR[T]) Dims() (rows, cols int) {
+	return c.M, c.N
+}
+
+// At returns the element at the 1-based row i and column j, which is zero if it isn't
+// stored. Panics if the index is out of range.
+func (c *CSR[T]) At(i, j int) T {
+	if i < 1 || i > c.M || j < 1 || j > c.N {
+		panic(fmt.Sprintf("matrix index error: index (%d, %d) out of range for %dx%d matrix", i, j, c.M, c.N))
+	}
+	lo, hi := c.RowPtr[i-1], c.RowPtr[i]
+	if k, ok := slices.BinarySearch(c.ColIdx[lo:hi], j-1); ok {
+		return c.Data[lo+k]
+	}
+	return 0
+}
+
+// NNZ returns the number of stored elements.
+func (c *CSR[T]) NNZ() int {
+	return len(c.Data)
+}
+
+// Dense returns a copy of c as a general matrix.
+func (c *CSR[T]) Dense() *Mat[T] {
+	d := &Mat[T]{M: c.M, N: c.N, Data: make([]T, c.M*c.N)}
+	for i := range c.M {
+		for k := c.RowPtr[i]; k < c.RowPtr[i+1]; k++ {
+			d.Data[i*c.N+c.ColIdx[k]] = c.Data[k]
+		}
+	}
+	return d
+}
+
+// String returns the dimensions and the number of stored elements, as sparse matrices
+// are usually too large to print.
+func (c *CSR[T]) String() string {
+	return fmt.Sprintf("%dx%d sparse matrix with %d non-zeros", c.M, c.N, len(c.Data))
+}
+
+// MulVecCSR calculates the matrix-vector product c v in time proportional to the number
+// of stored elements.
+func MulVecCSR[T number.Num](c *CSR[T], v *Vec[T]) (*Vec[T], error) {
+	if c.N != len(v.Data) {
+		return nil, fmt.Errorf("cannot multiply matrix and vector: Number of columns in the matrix (%d) must be equal to the length of the vector (%d)", c.N, len(v.Data))
+	}
+
+	data := make([]T, c.M)
+	for i := range data {
+		var sum T
+		for k := c.RowPtr[i]; k < c.RowPtr[i+1]; k++ {
+			sum += c.Data[k] * v.Data[c.ColIdx[k]]
+		}
+		data[i] = sum
+	}
+	return &Vec[T]{Data: data}, nil
+}
+
+// csrBuilder collects elements in any order, with repeats, and compresses them into a CSR.
+type csrBuilder[T number.Num] struct {
+	m, n       int
+	rows, cols []int
+	vals       []T
+}
+
+// add records the 0-based element (i, j). Repeated elements are summed by build.
+func (b *csrBuilder[T]) add(i, j int, v T) {
+	if v == 0 {
+		return
+	}
+	b.rows = append(b.rows, i)
+	b.cols = append(b.cols, j)
+	b.vals = append(b.vals, v)
+}
+
+func (b *csrBuilder[T]) build() *CSR[T] {
+	c := &CSR[T]{M: b.m, N: b.n, RowPtr: make([]int, b.m+1)}
+
+	// Bucket the elements by row
+	for _, i := range b.rows {
+		c.RowPtr[i+1]++
+	}
+	for i := range b.m {
+		c.RowPtr[i+1] += c.RowPtr[i]
+	}
+	next := slices.Clone(c.RowPtr[:b.m])
+	cols := make([]int, len(b.rows))
+	vals := make([]T, len(b.rows))
+	for k, i := range b.rows {
+		cols[next[i]], vals[next[i]] = b.cols[k], b.vals[k]
+		next[i]++
+	}
+
+	// Sort each row by column and sum repeats, compacting in place
+	c.ColIdx, c.Data = cols[:0], vals[:0]
+	var order, rowCols []int
+	var rowVals []T
+	start := 0
+	for i := range b.m {
+		end := c.RowPtr[i+1]
+		order = order[:0]
+		for k := start; k < end; k++ {
+			order = append(order, k)
+		}
+		slices.SortStableFunc(order, func(x, y int) int { return cols[x] - cols[y] })
+
+		// The row is merged into buffers first, as the output overwrites cols and vals
+		rowCols, rowVals = rowCols[:0], rowVals[:0]
+		for _, k := range order {
+			if n := len(rowCols); n > 0 && rowCols[n-1] == cols[k] {
+				rowVals[n-1] += vals[k]
+				continue
+			}
+			rowCols = append(rowCols, cols[k])
+			rowVals = append(rowVals, vals[k])
+		}
+		for k := range rowCols {
+			if rowVals[k] != 0 {
+				c.ColIdx = append(c.ColIdx, rowCols[k])
+				c.Data = append(c.Data, rowVals[k])
+			}
+		}
+
+		start = end
+		c.RowPtr[i+1] = len(c.Data)
+	}
+	return c
+}
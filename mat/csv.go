@@ -0,0 +1,235 @@
+package mat
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/lattots/gonum/number"
+)
+
+// MissingPolicy decides what ReadCSV does with empty fields and fields listed in
+// CSVOptions.MissingValues.
+type MissingPolicy int
+
+const (
+	// MissingError rejects missing values with a *ParseError. This is the default.
+	MissingError MissingPolicy = iota
+	// MissingZero replaces missing values with 0.
+	MissingZero
+	// MissingNaN replaces missing values with NaN. Only valid for float matrices.
+	MissingNaN
+	// MissingFill replaces missing values with CSVOptions.Fill.
+	MissingFill
+)
+
+// CSVOptions controls how ReadCSV parses delimited text.
+type CSVOptions struct {
+	// Comma is the field delimiter. It defaults to ',' and can be set to '\t' for TSV input.
+	Comma rune
+	// Comment, if not 0, marks lines starting with it as comments to be skipped.
+	Comment rune
+	// SkipRows is the number of leading records to skip, e.g. 1 for a header row.
+	SkipRows int
+	// Missing is the policy for empty fields and fields listed in MissingValues.
+	Missing MissingPolicy
+	// Fill is the replacement value used with MissingFill.
+	Fill float64
+	// MissingValues lists additional tokens, such as "NA", that count as missing.
+	MissingValues []string
+}
+
+// ReadCSV parses delimited text into a matrix with one row per record. Every field is
+// parsed strictly into T, so fractional values are rejected for integer matrices.
+// All records must have the same number of fields. Failures are reported as a
+// *ParseError holding the line and 1-based field number of the offending value, or
+// for syntax errors such as a stray quote the line and 1-based byte column. In input
+// with a single column an empty line between records is a missing value, while empty
+// lines after the last record are ignored.
+func ReadCSV[T number.Num](r io.Reader, opts CSVOptions) (*Mat[T], error) {
+	fill, err := missingFill[T](opts)
+	if err != nil {
+		return nil, err
+	}
+
+	blanks := &blankLines{r: r, line: 1, empty: true, blank: make(map[int]bool)}
+	cr := csv.NewReader(blanks)
+	cr.Comma = ','
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+	cr.Comment = opts.Comment
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	var data []T
+	cols := -1
+	record := 0
+	// lastLine is the line the previous record ended on
+	lastLine := 0
+
+	for {
+		fields, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var csvErr *csv.ParseError
+			if errors.As(err, &csvErr) {
+				return nil, &ParseError{Line: csvErr.Line, Column: csvErr.Column, Err: csvErr.Err}
+			}
+			return nil, err
+		}
+
+		line, _ := cr.FieldPos(0)
+		gap := lastLine + 1
+		lastLine = line
+		for _, f := range fields {
+			lastLine += strings.Count(f, "\n")
+		}
+
+		record++
+		if record <= opts.SkipRows {
+			continue
+		}
+
+		if cols == -1 {
+			cols = len(fields)
+		} else if len(fields) != cols {
+			return nil, &ParseError{Line: line, Err: fmt.Errorf("record has %d fields, expected %d", len(fields), cols)}
+		}
+
+		// encoding/csv skips empty lines, which in a single column are missing values
+		if cols == 1 {
+			for l := gap; l < line; l++ {
+				if !blanks.blank[l] {
+					continue
+				}
+				if opts.Missing == MissingError {
+					return nil, &ParseError{Line: l, Column: 1, Err: fmt.Errorf("missing value")}
+				}
+				data = append(data, fill)
+			}
+		}
+
+		for i, f := range fields {
+			f = strings.TrimSpace(f)
+
+			if isMissing(f, opts.MissingValues) {
+				if opts.Missing == MissingError {
+					line, _ := cr.FieldPos(i)
+					return nil, &ParseError{Line: line, Column: i + 1, Err: fmt.Errorf("missing value")}
+				}
+				data = append(data, fill)
+				continue
+			}
+
+			v, err := parseNum[T](f)
+			if err != nil {
+				line, _ := cr.FieldPos(i)
+				return nil, &ParseError{Line: line, Column: i + 1, Err: err}
+			}
+			data = append(data, v)
+		}
+	}
+
+	if cols <= 0 {
+		return nil, fmt.Errorf("can't initialize a matrix with no data")
+	}
+
+	return &Mat[T]{
+		M:    len(data) / cols,
+		N:    cols,
+		Data: data,
+	}, nil
+}
+
+// blankLines passes reads through and records the numbers of the empty lines, which
+// encoding/csv skips without a trace.
+type blankLines struct {
+	r     io.Reader
+	line  int
+	empty bool
+	blank map[int]bool
+}
+
+func (b *blankLines) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	for _, c := range p[:n] {
+		switch c {
+		case '\n':
+			if b.empty {
+				b.blank[b.line] = true
+			}
+			b.line++
+			b.empty = true
+		case '\r':
+		default:
+			b.empty = false
+		}
+	}
+	return n, err
+}
+
+func missingFill[T number.Num](opts CSVOptions) (T, error) {
+	switch opts.Missing {
+	case MissingError, MissingZero:
+		return 0, nil
+	case MissingNaN:
+		if !isFloat[T]() {
+			return 0, fmt.Errorf("missing value policy NaN requires a float matrix")
+		}
+		return T(math.NaN()), nil
+	case MissingFill:
+		if !isFloat[T]() && float64(T(opts.Fill)) != opts.Fill {
+			return 0, fmt.Errorf("fill value %g can't be represented exactly in an integer matrix", opts.Fill)
+		}
+		return T(opts.Fill), nil
+	default:
+		return 0, fmt.Errorf("unknown missing value policy %d", opts.Missing)
+	}
+}
+
+func isMissing(field string, missing []string) bool {
+	if field == "" {
+		return true
+	}
+	for _, m := range missing {
+		if field == m {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteCSV writes m as comma separated values, one record per row.
+// Floats are written with the fewest digits that read back exactly.
+func (m *Mat[T]) WriteCSV(w io.Writer) error {
+	return m.writeDelimited(w, ',')
+}
+
+// WriteTSV writes m as tab separated values, one record per row.
+func (m *Mat[T]) WriteTSV(w io.Writer) error {
+	return m.writeDelimited(w, '\t')
+}
+
+func (m *Mat[T]) writeDelimited(w io.Writer, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	record := make([]string, m.N)
+	for r := 0; r < m.M; r++ {
+		for c := 0; c < m.N; c++ {
+			record[c] = formatNum(m.Data[r*m.N+c])
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,85 @@
+package mat
+
+import (
+	"fmt"
+
+	"github.com/lattots/gonum/number"
+)
+
+// DiagDense is an n x n diagonal matrix. Only the n diagonal elements are stored.
+type DiagDense[T number.Num] struct {
+	Data []T
+}
+
+// NewDiagDense creates a diagonal matrix with a copy of d on the diagonal.
+func NewDiagDense[T number.Num](d []T) (*DiagDense[T], error) {
+	if len(d) == 0 {
+		return nil, fmt.Errorf("can't initialize a matrix with no data")
+	}
+	return &DiagDense[T]{Data: append([]T(nil), d...)}, nil
+}
+
+// Dims returns the number of rows and columns of d.
+func (d *DiagDense[T]) Dims() (rows, cols int) {
+	return len(d.Data), len(d.Data)
+}
+
+// At returns the element at the 1-based row i and column j, which is zero off the
+// diagonal. Panics if the index is out of range.
+func (d *DiagDense[T]) At(i, j int) T {
+	checkSquareIndex(i, j, len(d.Data))
+	if i != j {
+		return 0
+	}
+	return d.Data[i-1]
+}
+
+// SetDiag sets the 1-based i-th diagonal element. Panics if i is out of range.
+func (d *DiagDense[T]) SetDiag(i int, v T) {
+	checkSquareIndex(i, i, len(d.Data))
+	d.Data[i-1] = v
+}
+
+// Dense returns a copy of d as a general matrix.
+func (d *DiagDense[T]) Dense() *Mat[T] {
+	return toDense[T](d)
+}
+
+func (d *DiagDense[T]) String() string {
+	return d.Dense().String()
+}
+
+// MulDiag calculates d m, which scales the rows of m by the diagonal, in O(n²) instead
+// of the O(n³) of a general product. Returns an error if the shapes don't match.
+func MulDiag[T number.Num](d *DiagDense[T], m *Mat[T]) (*Mat[T], error) {
+	if len(d.Data) != m.M {
+		return nil, shapeErrorf("MulDiag", "cannot multiply %dx%d diagonal matrix with %dx%d matrix", len(d.Data), len(d.Data), m.M, m.N)
+	}
+
+	res := &Mat[T]{M: m.M, N: m.N, Data: make([]T, len(m.Data))}
+	for i, di := range d.Data {
+		for j := range m.N {
+			res.Data[i*m.N+j] = di * m.Data[i*m.N+j]
+		}
+	}
+	return res, nil
+}
+
+// SolveDiag solves d X = b by dividing the rows of b by the diagonal. Returns
+// ErrSingular if an element on the diagonal is zero.
+func SolveDiag[T number.Float](d *DiagDense[T], b *Mat[T]) (*Mat[T], error) {
+	if len(d.Data) != b.M {
+		return nil, shapeErrorf("SolveDiag", "right-hand side has %d rows, need %d", b.M, len(d.Data))
+	}
+
+	x := &Mat[T]{M: b.M, N: b.N, Data: make([]T, len(b.Data))}
+	for i, di := range d.Data {
+		if di == 0 {
+			return nil, ErrSingular
+		}
+		for j := range b.N {
+			x.Data[i*b.N+j] = b.Data[i*b.N+j] / di
+		}
+	}
+	return x, nil
+}
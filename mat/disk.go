@@ -0,0 +1,412 @@
+package mat
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/lattots/gonum/number"
+)
+
+// diskTile is the edge length of the square tiles that DotDisk and TransposeDisk
+// keep in memory at a time.
+const diskTile = 512
+
+// diskBandElems is the number of elements in each band of rows streamed by the reductions.
+const diskBandElems = 1 << 20
+
+// DiskMat is a matrix stored in a file in the binary format of MarshalBinary.
+// On Unix systems the file is memory-mapped, elsewhere it is read and written
+// with positioned file I/O. Either way only the parts that are accessed are brought
+// into memory, so a DiskMat can be much larger than RAM.
+//
+// Elements are accessed in blocks with Block and SetBlock, which copy between the
+// file and ordinary matrices. Writes to disjoint blocks may happen concurrently.
+// DiskMat implements Matrix, but At reads one element at a time, so passing a large
+// DiskMat to the in-memory functions is slow and copies it into memory.
+type DiskMat[T number.Num] struct {
+	M int
+	N int
+
+	file  *os.File
+	store *diskStore
+	order binary.ByteOrder
+}
+
+// CreateDisk creates a file at path holding an m x n matrix of zeros, truncating
+// any existing file. On file systems with sparse file support the zeros take no
+// space until they are written.
+func CreateDisk[T number.Num](path string, m, n int) (*DiskMat[T], error) {
+	if m <= 0 || n <= 0 {
+		return nil, fmt.Errorf("dimensions of matrices must be above zero")
+	}
+	if uint64(m) > uint64(maxDiskElems[T]())/uint64(n) {
+		return nil, fmt.Errorf("disk matrix dimensions %dx%d are too large", m, n)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, binaryHeaderSize)
+	encodeBinaryHeader[T](header, m, n)
+
+	size := int64(binaryHeaderSize) + int64(m)*int64(n)*int64(binaryElemSize[T]())
+	if err := f.Truncate(size); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.WriteAt(header, 0); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return openDisk[T](f, binaryHeader{tag: binaryTag[T](), order: binary.LittleEndian, m: m, n: n}, size, true)
+}
+
+// OpenDisk opens a matrix file written by CreateDisk or MarshalBinary. flag is
+// os.O_RDONLY or os.O_RDWR; writing to a read-only matrix returns an error.
+func OpenDisk[T number.Num](path string, flag int) (*DiskMat[T], error) {
+	if flag != os.O_RDONLY && flag != os.O_RDWR {
+		return nil, fmt.Errorf("disk matrix can only be opened with os.O_RDONLY or os.O_RDWR")
+	}
+
+	f, err := os.OpenFile(path, flag, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, binaryHeaderSize)
+	if _, err := io.ReadFull(f, header); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to read disk matrix header: %w", err)
+	}
+	h, err := decodeBinaryHeader[T](header)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	size := int64(binaryHeaderSize) + int64(h.m)*int64(h.n)*int64(binaryElemSize[T]())
+	if info.Size() != size {
+		f.Close()
+		return nil, fmt.Errorf("disk matrix file has %d bytes, expected %d for %dx%d matrix", info.Size(), size, h.m, h.n)
+	}
+
+	return openDisk[T](f, h, size, flag == os.O_RDWR)
+}
+
+func openDisk[T number.Num](f *os.File, h binaryHeader, size int64, writable bool) (*DiskMat[T], error) {
+	store, err := newDiskStore(f, size, writable)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &DiskMat[T]{
+		M:     h.m,
+		N:     h.n,
+		file:  f,
+		store: store,
+		order: h.order,
+	}, nil
+}
+
+// maxDiskElems is the largest number of elements of type T that fit in a file that can be mapped.
+func maxDiskElems[T number.Num]() int {
+	return (math.MaxInt - binaryHeaderSize) / binaryElemSize[T]()
+}
+
+// Sync flushes written elements to the file and the file to stable storage.
+func (d *DiskMat[T]) Sync() error {
+	if err := d.store.sync(); err != nil {
+		return err
+	}
+	return d.file.Sync()
+}
+
+// Close flushes written elements and releases the file. The matrix can't be used afterwards.
+func (d *DiskMat[T]) Close() error {
+	err := d.store.close()
+	if cerr := d.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Dims returns the number of rows and columns of d.
+func (d *DiskMat[T]) Dims() (rows, cols int) {
+	return d.M, d.N
+}
+
+// At returns the element in row i and column j using 1-based indices like Mat.At.
+// Panics if the indices are out of range or the file can't be read.
+func (d *DiskMat[T]) At(i, j int) T {
+	if i < 1 || i > d.M || j < 1 || j > d.N {
+		panic(fmt.Sprintf("matrix index error: index (%d, %d) out of range for %dx%d matrix", i, j, d.M, d.N))
+	}
+
+	b := make([]byte, binaryElemSize[T]())
+	if err := d.store.readAt(b, d.offset(i-1, j-1)); err != nil {
+		panic(fmt.Sprintf("matrix io error: %v", err))
+	}
+
+	v := make([]T, 1)
+	decodeBinaryData(b, d.order, v)
+	return v[0]
+}
+
+// Block reads the submatrix made of rows [r0, r1) and columns [c0, c1) into memory.
+// Unlike Sub, the bounds are not clamped: bounds that are out of range or empty are
+// returned as a *ShapeError, like failures to read the file.
+func (d *DiskMat[T]) Block(r0, r1, c0, c1 int) (*Mat[T], error) {
+	if r0 < 0 || r1 > d.M || r0 >= r1 || c0 < 0 || c1 > d.N || c0 >= c1 {
+		return nil, shapeErrorf("Block", "block [%d:%d, %d:%d] out of range for %dx%d matrix", r0, r1, c0, c1, d.M, d.N)
+	}
+
+	rows, cols := r1-r0, c1-c0
+	size := binaryElemSize[T]()
+	data := make([]T, rows*cols)
+
+	// Whole rows are contiguous in the file and can be read at once
+	if cols == d.N {
+		b := make([]byte, len(data)*size)
+		if err := d.store.readAt(b, d.offset(r0, 0)); err != nil {
+			return nil, err
+		}
+		decodeBinaryData(b, d.order, data)
+		return &Mat[T]{M: rows, N: cols, Data: data}, nil
+	}
+
+	b := make([]byte, cols*size)
+	for r := 0; r < rows; r++ {
+		if err := d.store.readAt(b, d.offset(r0+r, c0)); err != nil {
+			return nil, err
+		}
+		decodeBinaryData(b, d.order, data[r*cols:(r+1)*cols])
+	}
+
+	return &Mat[T]{M: rows, N: cols, Data: data}, nil
+}
+
+// SetBlock writes src into d with its top left corner at row r0 and column c0
+// using 0-based indices like SetSub. Returns a *ShapeError if src doesn't fit.
+func (d *DiskMat[T]) SetBlock(r0, c0 int, src *Mat[T]) error {
+	if r0 < 0 || c0 < 0 || r0+src.M > d.M || c0+src.N > d.N {
+		return shapeErrorf("SetBlock", "%dx%d block at (%d, %d) doesn't fit in %dx%d matrix", src.M, src.N, r0, c0, d.M, d.N)
+	}
+
+	size := binaryElemSize[T]()
+
+	if src.N == d.N {
+		b := make([]byte, len(src.Data)*size)
+		encodeBinaryData(b, d.order, src.Data)
+		return d.store.writeAt(b, d.offset(r0, 0))
+	}
+
+	b := make([]byte, src.N*size)
+	for r := 0; r < src.M; r++ {
+		encodeBinaryData(b, d.order, src.Data[r*src.N:(r+1)*src.N])
+		if err := d.store.writeAt(b, d.offset(r0+r, c0)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// offset is the position of the element in row r and column c (0-based) in the file.
+func (d *DiskMat[T]) offset(r, c int) int64 {
+	return int64(binaryHeaderSize) + (int64(r)*int64(d.N)+int64(c))*int64(binaryElemSize[T]())
+}
+
+// sameFile reports whether d and o are backed by the same file, either because they
+// are the same DiskMat or because the file was opened twice.
+func (d *DiskMat[T]) sameFile(o *DiskMat[T]) bool {
+	if d == o {
+		return true
+	}
+	di, err := d.file.Stat()
+	if err != nil {
+		return false
+	}
+	oi, err := o.file.Stat()
+	if err != nil {
+		return false
+	}
+	return os.SameFile(di, oi)
+}
+
+// DotDisk computes the matrix product of a and b into dst one tile at a time.
+// Only three tiles of diskTile x diskTile elements are held in memory at once,
+// so the operands and the result can all be larger than RAM. dst must not share its
+// file with a or b, as tiles of the result would overwrite operand tiles that are
+// still to be read.
+func DotDisk[T number.Num](dst, a, b *DiskMat[T]) error {
+	if dst.sameFile(a) || dst.sameFile(b) {
+		return fmt.Errorf("destination of DotDisk must not be stored in the same file as an operand")
+	}
+	if a.N != b.M {
+		return fmt.Errorf("cannot multiply matrices: Number of columns in the first matrix (%d) must be equal to the number of rows in the second matrix (%d)", a.N, b.M)
+	}
+	if dst.M != a.M || dst.N != b.N {
+		return shapeErrorf("DotDisk", "destination is %dx%d, product is %dx%d", dst.M, dst.N, a.M, b.N)
+	}
+
+	for i := 0; i < a.M; i += diskTile {
+		i1 := min(i+diskTile, a.M)
+		for j := 0; j < b.N; j += diskTile {
+			j1 := min(j+diskTile, b.N)
+
+			var acc *Mat[T]
+			for k := 0; k < a.N; k += diskTile {
+				k1 := min(k+diskTile, a.N)
+
+				aTile, err := a.Block(i, i1, k, k1)
+				if err != nil {
+					return err
+				}
+				bTile, err := b.Block(k, k1, j, j1)
+				if err != nil {
+					return err
+				}
+
+				prod, err := Dot(aTile, bTile)
+				if err != nil {
+					return err
+				}
+				if acc == nil {
+					acc = prod
+				} else {
+					acc = Sum(acc, prod)
+				}
+			}
+
+			if err := dst.SetBlock(i, j, acc); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// TransposeDisk writes the transpose of src into dst one tile at a time. dst must not
+// share its file with src.
+func TransposeDisk[T number.Num](dst, src *DiskMat[T]) error {
+	if dst.sameFile(src) {
+		return fmt.Errorf("destination of TransposeDisk must not be stored in the same file as the source")
+	}
+	if dst.M != src.N || dst.N != src.M {
+		return shapeErrorf("TransposeDisk", "destination is %dx%d, transpose is %dx%d", dst.M, dst.N, src.N, src.M)
+	}
+
+	for r := 0; r < src.M; r += diskTile {
+		for c := 0; c < src.N; c += diskTile {
+			tile, err := src.Block(r, min(r+diskTile, src.M), c, min(c+diskTile, src.N))
+			if err != nil {
+				return err
+			}
+			if err := dst.SetBlock(c, r, Transpose(tile)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// reduceDisk streams d through memory in bands of whole rows. partial reduces a band
+// along axis, and for AxisAll and AxisRows the partial results of the bands are
+// combined element by element with combine.
+func reduceDisk[T, R number.Num](op string, d *DiskMat[T], axis Axis, partial func(*Mat[T]) *Mat[R], combine func(a, b R) R) (*Mat[R], error) {
+	if !axis.valid() {
+		panic(fmt.Sprintf("matrix math error: invalid axis %d for %s", int(axis), op))
+	}
+
+	bandRows := max(1, diskBandElems/d.N)
+
+	var res *Mat[R]
+	if axis == AxisCols {
+		res = &Mat[R]{M: d.M, N: 1, Data: make([]R, d.M)}
+	}
+
+	for r := 0; r < d.M; r += bandRows {
+		band, err := d.Block(r, min(r+bandRows, d.M), 0, d.N)
+		if err != nil {
+			return nil, err
+		}
+		part := partial(band)
+
+		switch {
+		case axis == AxisCols:
+			copy(res.Data[r:], part.Data)
+		case res == nil:
+			res = part
+		default:
+			for i := range res.Data {
+				res.Data[i] = combine(res.Data[i], part.Data[i])
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// SumAxisDisk sums the elements of d along axis like SumAxis. Each band of rows is
+// summed with the strategy set with SetSummation and the band sums are then added up.
+func SumAxisDisk[T number.Num](d *DiskMat[T], axis Axis) (*Mat[T], error) {
+	return reduceDisk("sum", d, axis, func(band *Mat[T]) *Mat[T] {
+		return SumAxis(band, axis)
+	}, func(a, b T) T { return a + b })
+}
+
+// MeanAxisDisk calculates the arithmetic mean of d along axis like MeanAxis.
+func MeanAxisDisk[T number.Num](d *DiskMat[T], axis Axis) (*Mat[float64], error) {
+	res, err := reduceDisk("mean", d, axis, func(band *Mat[T]) *Mat[float64] {
+		means := MeanAxis(band, axis)
+		if axis == AxisCols {
+			return means
+		}
+		// Weight the band means by the band height so that they can be added up
+		return Scale(means, float64(band.M))
+	}, func(a, b float64) float64 { return a + b })
+	if err != nil || axis == AxisCols {
+		return res, err
+	}
+
+	return Scale(res, 1/float64(d.M)), nil
+}
+
+// MinAxisDisk finds the smallest elements of d along axis like MinAxis.
+func MinAxisDisk[T number.Num](d *DiskMat[T], axis Axis) (*Mat[T], error) {
+	return reduceDisk("minimum", d, axis, func(band *Mat[T]) *Mat[T] {
+		return MinAxis(band, axis)
+	}, func(a, b T) T {
+		// Compare like MinAxis so that NaNs are treated the same way
+		if b < a {
+			return b
+		}
+		return a
+	})
+}
+
+// MaxAxisDisk finds the largest elements of d along axis like MaxAxis.
+func MaxAxisDisk[T number.Num](d *DiskMat[T], axis Axis) (*Mat[T], error) {
+	return reduceDisk("maximum", d, axis, func(band *Mat[T]) *Mat[T] {
+		return MaxAxis(band, axis)
+	}, func(a, b T) T {
+		if b > a {
+			return b
+		}
+		return a
+	})
+}
@@ -0,0 +1,40 @@
+//go:build !unix
+
+package mat
+
+import (
+	"errors"
+	"os"
+)
+
+// diskStore gives access to the file of a DiskMat with positioned reads and writes
+// on systems without memory mapping.
+type diskStore struct {
+	f        *os.File
+	writable bool
+}
+
+func newDiskStore(f *os.File, size int64, writable bool) (*diskStore, error) {
+	return &diskStore{f: f, writable: writable}, nil
+}
+
+func (s *diskStore) readAt(b []byte, off int64) error {
+	_, err := s.f.ReadAt(b, off)
+	return err
+}
+
+func (s *diskStore) writeAt(b []byte, off int64) error {
+	if !s.writable {
+		return errors.New("disk matrix is read-only")
+	}
+	_, err := s.f.WriteAt(b, off)
+	return err
+}
+
+func (s *diskStore) sync() error {
+	return nil
+}
+
+func (s *diskStore) close() error {
+	return nil
+}
@@ -0,0 +1,76 @@
+//go:build unix
+
+package mat
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// diskStore gives access to the file of a DiskMat through a shared memory mapping.
+type diskStore struct {
+	data     []byte
+	writable bool
+}
+
+func newDiskStore(f *os.File, size int64, writable bool) (*diskStore, error) {
+	prot := syscall.PROT_READ
+	if writable {
+		prot |= syscall.PROT_WRITE
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), prot, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map disk matrix: %w", err)
+	}
+
+	return &diskStore{data: data, writable: writable}, nil
+}
+
+func (s *diskStore) readAt(b []byte, off int64) error {
+	if s.data == nil {
+		return errors.New("disk matrix is closed")
+	}
+	copy(b, s.data[off:])
+	return nil
+}
+
+func (s *diskStore) writeAt(b []byte, off int64) error {
+	if s.data == nil {
+		return errors.New("disk matrix is closed")
+	}
+	if !s.writable {
+		return errors.New("disk matrix is read-only")
+	}
+	copy(s.data[off:], b)
+	return nil
+}
+
+// sync writes the pages changed through the mapping back to the file. POSIX only
+// guarantees that writes to a shared mapping reach the file after msync, so syncing
+// the file alone isn't enough on every system.
+func (s *diskStore) sync() error {
+	if s.data == nil {
+		return errors.New("disk matrix is closed")
+	}
+	if !s.writable {
+		return nil
+	}
+	if err := unix.Msync(s.data, unix.MS_SYNC); err != nil {
+		return fmt.Errorf("failed to sync disk matrix: %w", err)
+	}
+	return nil
+}
+
+func (s *diskStore) close() error {
+	if s.data == nil {
+		return nil
+	}
+	err := syscall.Munmap(s.data)
+	s.data = nil
+	return err
+}
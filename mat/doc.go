@@ -0,0 +1,26 @@
+// Package mat provides generic dense, structured and sparse matrices over the number
+// types together with the usual matrix arithmetic, reductions and decompositions.
+//
+// Floating point sums in Dot, VectorDot, MulVec, Vec.Dot, SumRows, SumColumns and
+// SumAxis are accumulated with pairwise summation by default. The dot products and the
+// row and column sums used to accumulate left to right, so their float results can
+// differ from earlier versions in the last bits. Call SetSummation(NaiveSummation) to
+// restore the old behavior for the whole program, or pass NaiveSummation to one of the
+// *With variants such as DotWith for a single call. Integer sums are exact and don't
+// depend on the strategy.
+//
+// Dot switches to Strassen's algorithm above a crossover size, see StrassenThreshold.
+// The crossover depends on the element type and the number of cores, and can be tuned
+// per machine: `gonum-bench tune` measures it and saves it to the tuning file at
+// TuningPath. Reading the file is opt-in, so a program that wants the tuned values
+// loads them once at startup, before its first Dot:
+//
+//	if path, err := mat.TuningPath(); err == nil {
+//		if err := mat.LoadTuning(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+//			log.Print(err)
+//		}
+//	}
+//
+// From then on every Dot uses the tuned crossover for the current GOMAXPROCS.
+// SetStrassenThreshold overrides it for one element type.
+package mat
@@ -0,0 +1,43 @@
+package mat
+
+import "fmt"
+
+// ShapeError is returned when the dimensions of the operands are not valid
+// for the requested operation.
+type ShapeError struct {
+	// Op is the name of the operation that failed, e.g. "HStack".
+	Op string
+	// Reason describes the mismatch in human readable form.
+	Reason string
+}
+
+func (e *ShapeError) Error() string {
+	return fmt.Sprintf("matrix shape error: %s: %s", e.Op, e.Reason)
+}
+
+func shapeErrorf(op, format string, args ...any) error {
+	return &ShapeError{Op: op, Reason: fmt.Sprintf(format, args...)}
+}
+
+// ParseError reports malformed input found while decoding a text format.
+type ParseError struct {
+	// Line is the 1-based line number of the offending input.
+	Line int
+	// Column is the 1-based field number on that line, the 1-based byte column
+	// for syntax errors in delimited text, or 0 if the error concerns the line
+	// as a whole.
+	Column int
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf("parse error on line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("parse error on line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
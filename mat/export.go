@@ -0,0 +1,114 @@
+package mat
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lattots/gonum/number"
+)
+
+// FormatLaTeX renders a as a LaTeX bmatrix environment.
+// Floats are printed with prec digits after the decimal point, or with the fewest
+// digits that read back exactly if prec is negative. Integers ignore prec.
+func FormatLaTeX[T number.Num](a Matrix[T], prec int) string {
+	m := DenseOf(a)
+	var sb strings.Builder
+	sb.WriteString("\\begin{bmatrix}\n")
+
+	for r := 0; r < m.M; r++ {
+		row := make([]string, m.N)
+		for c := range row {
+			row[c] = exportElement(m.Data[r*m.N+c], prec, `\mathrm{NaN}`, `\infty`)
+		}
+		sb.WriteString(strings.Join(row, " & "))
+		if r < m.M-1 {
+			sb.WriteString(` \\`)
+		}
+		sb.WriteByte('\n')
+	}
+
+	sb.WriteString("\\end{bmatrix}")
+	return sb.String()
+}
+
+// FormatMarkdown renders a as a Markdown table with right-aligned columns.
+// The header row holds the 1-based column numbers. prec works as in FormatLaTeX.
+func FormatMarkdown[T number.Num](a Matrix[T], prec int) string {
+	m := DenseOf(a)
+	var sb strings.Builder
+
+	header := make([]string, m.N)
+	rule := make([]string, m.N)
+	for c := range header {
+		header[c] = strconv.Itoa(c + 1)
+		rule[c] = "---:"
+	}
+	sb.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	sb.WriteString("| " + strings.Join(rule, " | ") + " |\n")
+
+	for r := 0; r < m.M; r++ {
+		row := make([]string, m.N)
+		for c := range row {
+			row[c] = exportElement(m.Data[r*m.N+c], prec, "NaN", "Inf")
+		}
+		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+
+	return sb.String()
+}
+
+// FormatMATLAB renders a as a MATLAB/Octave matrix literal such as [1 2; 3 4].
+// prec works as in FormatLaTeX.
+func FormatMATLAB[T number.Num](a Matrix[T], prec int) string {
+	m := DenseOf(a)
+	rows := make([]string, m.M)
+	for r := range rows {
+		row := make([]string, m.N)
+		for c := range row {
+			row[c] = exportElement(m.Data[r*m.N+c], prec, "NaN", "Inf")
+		}
+		rows[r] = strings.Join(row, " ")
+	}
+	return "[" + strings.Join(rows, "; ") + "]"
+}
+
+// FormatNumPy renders a as a NumPy expression such as np.array([[1, 2], [3, 4]]).
+// prec works as in FormatLaTeX. Float elements always have a decimal point or an
+// exponent, as in np.array([[1.0, 0.5]]), so that NumPy infers a float dtype.
+func FormatNumPy[T number.Num](a Matrix[T], prec int) string {
+	m := DenseOf(a)
+	rows := make([]string, m.M)
+	for r := range rows {
+		row := make([]string, m.N)
+		for c := range row {
+			row[c] = exportElement(m.Data[r*m.N+c], prec, "np.nan", "np.inf")
+			if isFloat[T]() && !strings.ContainsAny(row[c], ".en") {
+				row[c] += ".0"
+			}
+		}
+		rows[r] = "[" + strings.Join(row, ", ") + "]"
+	}
+	return "np.array([" + strings.Join(rows, ", ") + "])"
+}
+
+// exportElement formats v for an export format that spells NaN and infinity as nan and inf.
+func exportElement[T number.Num](v T, prec int, nan, inf string) string {
+	if !isFloat[T]() {
+		return formatNum(v)
+	}
+
+	f := float64(v)
+	switch {
+	case math.IsNaN(f):
+		return nan
+	case math.IsInf(f, 1):
+		return inf
+	case math.IsInf(f, -1):
+		return "-" + inf
+	case prec < 0:
+		return formatNum(v)
+	default:
+		return strconv.FormatFloat(f, 'f', prec, bitSize[T]())
+	}
+}
@@ -0,0 +1,249 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lattots/gonum/number"
+)
+
+// Alignment controls how formatted elements are padded into columns.
+type Alignment int
+
+const (
+	// AlignAuto leaves the summary unaligned, as String does, and right-aligns
+	// full matrices and explicit numeric verbs.
+	AlignAuto Alignment = iota
+	// AlignNone separates elements with a single space without padding.
+	AlignNone
+	// AlignRight pads elements on the left so that each column has equal width.
+	AlignRight
+	// AlignLeft pads elements on the right so that each column has equal width.
+	AlignLeft
+)
+
+// defaultSummaryLimit is the largest number of rows or columns printed in full by String.
+const defaultSummaryLimit = 3
+
+// FormatOptions configures the output of Formatted.
+type FormatOptions struct {
+	// MaxRows and MaxCols are the largest dimensions that the %v summary prints in full.
+	// Larger matrices show the leading rows or columns, an ellipsis and the last one.
+	// Zero means the default of 3 used by String.
+	MaxRows, MaxCols int
+	// Align sets how elements are padded into columns.
+	Align Alignment
+}
+
+// Formatted wraps a so that it is printed by the fmt package using opts.
+// The supported verbs are the same as for Mat.Format.
+func Formatted[T number.Num](a Matrix[T], opts FormatOptions) fmt.Formatter {
+	m := DenseOf(a)
+	return formatted[T]{m: m, opts: opts}
+}
+
+type formatted[T number.Num] struct {
+	m    *Mat[T]
+	opts FormatOptions
+}
+
+func (x formatted[T]) Format(f fmt.State, verb rune) {
+	formatMatrix(f, verb, x.m, x.opts)
+}
+
+// Format implements fmt.Formatter.
+//
+//   - %v and %s print the same summary as String, truncating large matrices to their corners.
+//     A width or precision, as in %.4v, is applied to every element.
+//   - %+v prints every element, with columns aligned.
+//   - %#v prints a Go [][]T literal that can be passed back to New.
+//   - Numeric verbs such as %.6e, %8.3f, %g and %d print every element using the verb,
+//     its flags, width and precision, with columns aligned.
+func (m *Mat[T]) Format(f fmt.State, verb rune) {
+	formatMatrix(f, verb, m, FormatOptions{})
+}
+
+func formatMatrix[T number.Num](f fmt.State, verb rune, m *Mat[T], opts FormatOptions) {
+	if m == nil {
+		fmt.Fprint(f, "<nil>")
+		return
+	}
+
+	switch verb {
+	case 'v', 's':
+		if verb == 'v' && f.Flag('#') {
+			f.Write([]byte(goSyntax(m)))
+			return
+		}
+
+		elem := formatElement[T]
+		if spec := elementSpec(f); spec != "" {
+			elem = func(v T) string { return fmt.Sprintf("%"+spec+"v", v) }
+		}
+
+		align := opts.Align
+		full := f.Flag('+')
+		if align == AlignAuto {
+			align = AlignNone
+			if full {
+				align = AlignRight
+			}
+		}
+
+		f.Write([]byte(renderMatrix(m, elem, !full, opts, align)))
+	case 'e', 'E', 'f', 'F', 'g', 'G', 'd':
+		format := fmt.FormatString(f, verb)
+		elem := func(v T) string { return fmt.Sprintf(format, v) }
+
+		align := opts.Align
+		if align == AlignAuto {
+			align = AlignRight
+		}
+
+		f.Write([]byte(renderMatrix(m, elem, false, opts, align)))
+	default:
+		fmt.Fprintf(f, "%%!%c(*mat.Mat[%T]=%s)", verb, *new(T), m.String())
+	}
+}
+
+// elementSpec returns the width and precision of f as a format specifier fragment.
+func elementSpec(f fmt.State) string {
+	var sb strings.Builder
+	if w, ok := f.Width(); ok {
+		sb.WriteString(strconv.Itoa(w))
+	}
+	if p, ok := f.Precision(); ok {
+		sb.WriteString("." + strconv.Itoa(p))
+	}
+	return sb.String()
+}
+
+// renderMatrix prints the dimensions of m followed by its rows. With summary set, rows
+// and columns beyond the limits in opts are replaced with an ellipsis.
+func renderMatrix[T number.Num](m *Mat[T], elem func(T) string, summary bool, opts FormatOptions, align Alignment) string {
+	maxRows, maxCols := m.M, m.N
+	if summary {
+		maxRows, maxCols = opts.MaxRows, opts.MaxCols
+		if maxRows <= 0 {
+			maxRows = defaultSummaryLimit
+		}
+		if maxCols <= 0 {
+			maxCols = defaultSummaryLimit
+		}
+	}
+
+	// -1 represents a marker
+	rowsToPrint := summaryIndices(m.M, maxRows)
+	colsToPrint := summaryIndices(m.N, maxCols)
+
+	cells := make([][]string, 0, len(rowsToPrint))
+	widths := make([]int, len(colsToPrint))
+	for _, r := range rowsToPrint {
+		if r == -1 {
+			cells = append(cells, nil)
+			continue
+		}
+
+		row := make([]string, len(colsToPrint))
+		for i, c := range colsToPrint {
+			if c == -1 {
+				row[i] = "..."
+			} else {
+				row[i] = elem(m.Data[r*m.N+c])
+			}
+			widths[i] = max(widths[i], len(row[i]))
+		}
+		cells = append(cells, row)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%d x %d\n", m.M, m.N))
+
+	for _, row := range cells {
+		// Handle marker
+		if row == nil {
+			sb.WriteString("...\n")
+			continue
+		}
+
+		for i, cell := range row {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			pad := strings.Repeat(" ", widths[i]-len(cell))
+			switch align {
+			case AlignRight:
+				sb.WriteString(pad + cell)
+			case AlignLeft:
+				// Don't leave trailing spaces at the end of the line.
+				if i == len(row)-1 {
+					pad = ""
+				}
+				sb.WriteString(cell + pad)
+			default:
+				sb.WriteString(cell)
+			}
+		}
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
+
+// summaryIndices returns the indices printed out of n when at most limit fit,
+// with -1 marking the position of the ellipsis.
+func summaryIndices(n, limit int) []int {
+	var idx []int
+	if n <= limit {
+		for i := 0; i < n; i++ {
+			idx = append(idx, i)
+		}
+		return idx
+	}
+
+	for i := 0; i < limit-1; i++ {
+		idx = append(idx, i)
+	}
+	return append(idx, -1, n-1)
+}
+
+// goSyntax returns m as a Go [][]T composite literal.
+func goSyntax[T number.Num](m *Mat[T]) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[][]%T{", *new(T)))
+
+	for r := 0; r < m.M; r++ {
+		if r > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteByte('{')
+		for c := 0; c < m.N; c++ {
+			if c > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(goLiteral(m.Data[r*m.N+c]))
+		}
+		sb.WriteByte('}')
+	}
+
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// goLiteral formats v as a Go expression that evaluates to exactly v.
+func goLiteral[T number.Num](v T) string {
+	if isFloat[T]() {
+		f := float64(v)
+		switch {
+		case math.IsNaN(f):
+			return "math.NaN()"
+		case math.IsInf(f, 1):
+			return "math.Inf(1)"
+		case math.IsInf(f, -1):
+			return "math.Inf(-1)"
+		}
+	}
+	return formatNum(v)
+}
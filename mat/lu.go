@@ -0,0 +1,129 @@
+package mat
+
+import (
+	"math"
+
+	"github.com/lattots/gonum/number"
+)
+
+// luFactors is the LU factorization P A = L U of a square matrix with partial pivoting.
+// L has a unit diagonal and is stored below the diagonal of lu, U on and above it.
+type luFactors struct {
+	n   int
+	lu  []float64
+	piv []int
+}
+
+// luFactor factorizes the square matrix a. Returns ErrSingular if a pivot is zero.
+func luFactor(a *Mat[float64]) (*luFactors, error) {
+	n := a.M
+	f := &luFactors{n: n, lu: append([]float64(nil), a.Data...), piv: make([]int, n)}
+	lu := f.lu
+
+	for k := range n {
+		// Pick the largest remaining element of column k as the pivot
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(lu[i*n+k]) > math.Abs(lu[p*n+k]) {
+				p = i
+			}
+		}
+		f.piv[k] = p
+		if lu[p*n+k] == 0 {
+			return nil, ErrSingular
+		}
+		if p != k {
+			for j := range n {
+				lu[k*n+j], lu[p*n+j] = lu[p*n+j], lu[k*n+j]
+			}
+		}
+
+		pivot := lu[k*n+k]
+		for i := k + 1; i < n; i++ {
+			l := lu[i*n+k] / pivot
+			lu[i*n+k] = l
+			if l == 0 {
+				continue
+			}
+			for j := k + 1; j < n; j++ {
+				lu[i*n+j] -= l * lu[k*n+j]
+			}
+		}
+	}
+
+	return f, nil
+}
+
+// logAbsDet returns the natural logarithm of the absolute value of the determinant,
+// which doesn't overflow for large matrices like the determinant itself can.
+func (f *luFactors) logAbsDet() float64 {
+	var s float64
+	for k := range f.n {
+		s += math.Log(math.Abs(f.lu[k*f.n+k]))
+	}
+	return s
+}
+
+// solve returns X with A X = b, for a b with n rows.
+func (f *luFactors) solve(b *Mat[float64]) *Mat[float64] {
+	n, lu := f.n, f.lu
+	x := &Mat[float64]{M: b.M, N: b.N, Data: append([]float64(nil), b.Data...)}
+	cols := b.N
+
+	for k, p := range f.piv {
+		if p != k {
+			for j := range cols {
+				x.Data[k*cols+j], x.Data[p*cols+j] = x.Data[p*cols+j], x.Data[k*cols+j]
+			}
+		}
+	}
+
+	// Forward substitution with L, then back substitution with U
+	for i := range n {
+		for k := range i {
+			if l := lu[i*n+k]; l != 0 {
+				for j := range cols {
+					x.Data[i*cols+j] -= l * x.Data[k*cols+j]
+				}
+			}
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		for k := i + 1; k < n; k++ {
+			if u := lu[i*n+k]; u != 0 {
+				for j := range cols {
+					x.Data[i*cols+j] -= u * x.Data[k*cols+j]
+				}
+			}
+		}
+		d := lu[i*n+i]
+		for j := range cols {
+			x.Data[i*cols+j] /= d
+		}
+	}
+
+	return x
+}
+
+// inverse returns the inverse of the factorized matrix.
+func (f *luFactors) inverse() *Mat[float64] {
+	return f.solve(identity[float64](f.n))
+}
+
+// identity returns the n x n identity matrix.
+func identity[T number.Num](n int) *Mat[T] {
+	m := &Mat[T]{M: n, N: n, Data: make([]T, n*n)}
+	for i := range n {
+		m.Data[i*n+i] = 1
+	}
+	return m
+}
+
+// convert returns a copy of m with its elements converted to T.
+func convert[T, S number.Num](m *Mat[S]) *Mat[T] {
+	data := make([]T, len(m.Data))
+	for i, v := range m.Data {
+		data[i] = T(v)
+	}
+	return &Mat[T]{M: m.M, N: m.N, Data: data}
+}
@@ -0,0 +1,296 @@
+package mat
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/lattots/gonum/number"
+)
+
+// The binary format starts with a fixed size header followed by the elements in row-major order:
+//
+//	offset  size  field
+//	0       4     magic "GNMT"
+//	4       1     format version
+//	5       1     element type tag
+//	6       1     byte order of the header dimensions and data, 0 little-endian, 1 big-endian
+//	7       1     reserved, always 0
+//	8       8     number of rows as uint64
+//	16      8     number of columns as uint64
+//	24      ...   elements
+const (
+	binaryMagic      = "GNMT"
+	binaryVersion    = 1
+	binaryHeaderSize = 24
+
+	binaryLittleEndian = 0
+	binaryBigEndian    = 1
+)
+
+// Element type tags of the binary format. int is always stored with 64 bits so that
+// files are portable between 32-bit and 64-bit platforms.
+const (
+	tagInt8    = 1
+	tagInt16   = 2
+	tagInt32   = 3
+	tagInt64   = 4
+	tagInt     = 5
+	tagFloat32 = 6
+	tagFloat64 = 7
+)
+
+func binaryTag[T number.Num]() byte {
+	switch any(*new(T)).(type) {
+	case int8:
+		return tagInt8
+	case int16:
+		return tagInt16
+	case int32:
+		return tagInt32
+	case int64:
+		return tagInt64
+	case int:
+		return tagInt
+	case float32:
+		return tagFloat32
+	default:
+		return tagFloat64
+	}
+}
+
+// binaryElemSize is the size of a stored element of type T in bytes.
+func binaryElemSize[T number.Num]() int {
+	if binaryTag[T]() == tagInt {
+		return 8
+	}
+	return byteSize[T]()
+}
+
+// binaryHeader is the decoded form of the binary format header.
+type binaryHeader struct {
+	tag   byte
+	order binary.ByteOrder
+	m, n  int
+}
+
+func encodeBinaryHeader[T number.Num](b []byte, m, n int) {
+	copy(b, binaryMagic)
+	b[4] = binaryVersion
+	b[5] = binaryTag[T]()
+	b[6] = binaryLittleEndian
+	b[7] = 0
+	binary.LittleEndian.PutUint64(b[8:], uint64(m))
+	binary.LittleEndian.PutUint64(b[16:], uint64(n))
+}
+
+// decodeBinaryHeader validates the header in b against the element type T.
+func decodeBinaryHeader[T number.Num](b []byte) (binaryHeader, error) {
+	var h binaryHeader
+
+	if len(b) < binaryHeaderSize {
+		return h, fmt.Errorf("binary matrix data too short for header: %d bytes", len(b))
+	}
+	if string(b[:4]) != binaryMagic {
+		return h, fmt.Errorf("not binary matrix data: bad magic %q", b[:4])
+	}
+	if b[4] != binaryVersion {
+		return h, fmt.Errorf("unsupported binary matrix format version %d", b[4])
+	}
+
+	h.tag = b[5]
+	if want := binaryTag[T](); h.tag != want {
+		return h, fmt.Errorf("binary matrix element type tag %d does not match %T (tag %d)", h.tag, *new(T), want)
+	}
+
+	switch b[6] {
+	case binaryLittleEndian:
+		h.order = binary.LittleEndian
+	case binaryBigEndian:
+		h.order = binary.BigEndian
+	default:
+		return h, fmt.Errorf("invalid byte order flag %d in binary matrix header", b[6])
+	}
+
+	m, n := h.order.Uint64(b[8:]), h.order.Uint64(b[16:])
+	if m == 0 || n == 0 {
+		return h, fmt.Errorf("dimensions of matrices must be above zero")
+	}
+	if m > uint64(math.MaxInt)/n || m*n > uint64(math.MaxInt-binaryHeaderSize)/uint64(binaryElemSize[T]()) {
+		return h, fmt.Errorf("binary matrix dimensions %dx%d are too large", m, n)
+	}
+	h.m, h.n = int(m), int(n)
+
+	return h, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is a versioned header with
+// the element type, byte order and dimensions, followed by the little-endian elements.
+func (m *Mat[T]) MarshalBinary() ([]byte, error) {
+	if len(m.Data) != m.M*m.N {
+		return nil, fmt.Errorf("matrix data length %d does not match dimensions %dx%d", len(m.Data), m.M, m.N)
+	}
+
+	b := make([]byte, binaryHeaderSize+len(m.Data)*binaryElemSize[T]())
+	encodeBinaryHeader[T](b, m.M, m.N)
+	encodeBinaryData(b[binaryHeaderSize:], binary.LittleEndian, m.Data)
+
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The element type in the header must
+// match T, and the data must hold exactly M*N elements.
+func (m *Mat[T]) UnmarshalBinary(b []byte) error {
+	h, err := decodeBinaryHeader[T](b)
+	if err != nil {
+		return err
+	}
+
+	if want := binaryHeaderSize + h.m*h.n*binaryElemSize[T](); len(b) != want {
+		return fmt.Errorf("binary matrix data has %d bytes, expected %d for %dx%d matrix", len(b), want, h.m, h.n)
+	}
+
+	data := make([]T, h.m*h.n)
+	decodeBinaryData(b[binaryHeaderSize:], h.order, data)
+
+	m.M = h.m
+	m.N = h.n
+	m.Data = data
+	return nil
+}
+
+// encodeBinaryData writes elements in the binary format, widening int to 64 bits.
+func encodeBinaryData[T number.Num](b []byte, order binary.ByteOrder, data []T) {
+	if binaryTag[T]() != tagInt {
+		encodeNums(b, order, data)
+		return
+	}
+	for i, v := range data {
+		order.PutUint64(b[i*8:], uint64(v))
+	}
+}
+
+// decodeBinaryData reads elements in the binary format, narrowing 64-bit ints to int.
+func decodeBinaryData[T number.Num](b []byte, order binary.ByteOrder, data []T) {
+	if binaryTag[T]() != tagInt {
+		decodeNums(b, order, data)
+		return
+	}
+	for i := range data {
+		data[i] = T(int64(order.Uint64(b[i*8:])))
+	}
+}
+
+// GobEncode implements gob.GobEncoder using the binary encoding.
+func (m *Mat[T]) GobEncode() ([]byte, error) {
+	return m.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the binary encoding.
+func (m *Mat[T]) GobDecode(b []byte) error {
+	return m.UnmarshalBinary(b)
+}
+
+// jsonMat is the JSON representation of a matrix. It uses the same field names as
+// the default encoding of Mat so that previously encoded values still decode.
+type jsonMat[E any] struct {
+	M    int `json:"M"`
+	N    int `json:"N"`
+	Data []E `json:"Data"`
+}
+
+// jsonElem is an element that JSON numbers can't hold, NaN and the infinities, which
+// are encoded as the strings "NaN", "+Inf" and "-Inf" instead.
+type jsonElem[T number.Num] struct {
+	v T
+}
+
+func (e jsonElem[T]) MarshalJSON() ([]byte, error) {
+	switch f := float64(e.v); {
+	case math.IsNaN(f):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(f, 1):
+		return []byte(`"+Inf"`), nil
+	case math.IsInf(f, -1):
+		return []byte(`"-Inf"`), nil
+	}
+	return json.Marshal(e.v)
+}
+
+func (e *jsonElem[T]) UnmarshalJSON(b []byte) error {
+	// Integers can't hold the special values, so the strings fail to decode below
+	if isFloat[T]() {
+		var f float64
+		switch string(b) {
+		case `"NaN"`:
+			f = math.NaN()
+		case `"+Inf"`:
+			f = math.Inf(1)
+		case `"-Inf"`:
+			f = math.Inf(-1)
+		}
+		if f != 0 {
+			e.v = T(f)
+			return nil
+		}
+	}
+	return json.Unmarshal(b, &e.v)
+}
+
+// MarshalJSON implements json.Marshaler. Elements are JSON numbers, except for NaN,
+// +Inf and -Inf, which are encoded as the strings "NaN", "+Inf" and "-Inf".
+func (m *Mat[T]) MarshalJSON() ([]byte, error) {
+	if len(m.Data) != m.M*m.N {
+		return nil, fmt.Errorf("matrix data length %d does not match dimensions %dx%d", len(m.Data), m.M, m.N)
+	}
+
+	if isFloat[T]() && slices.ContainsFunc(m.Data, func(v T) bool { return math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) }) {
+		data := make([]jsonElem[T], len(m.Data))
+		for i, v := range m.Data {
+			data[i] = jsonElem[T]{v}
+		}
+		return json.Marshal(jsonMat[jsonElem[T]]{M: m.M, N: m.N, Data: data})
+	}
+	return json.Marshal(jsonMat[T]{M: m.M, N: m.N, Data: m.Data})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The dimensions must be above zero and
+// the data must hold exactly M*N elements. Float matrices also accept the strings
+// written by MarshalJSON for NaN and the infinities.
+func (m *Mat[T]) UnmarshalJSON(b []byte) error {
+	var j jsonMat[T]
+	err := json.Unmarshal(b, &j)
+	// Only data with special values is decoded element by element
+	var typeErr *json.UnmarshalTypeError
+	if isFloat[T]() && errors.As(err, &typeErr) {
+		var special jsonMat[jsonElem[T]]
+		if err = json.Unmarshal(b, &special); err == nil {
+			j = jsonMat[T]{M: special.M, N: special.N, Data: make([]T, len(special.Data))}
+			for i, e := range special.Data {
+				j.Data[i] = e.v
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if j.M <= 0 || j.N <= 0 {
+		return fmt.Errorf("dimensions of matrices must be above zero")
+	}
+	// M*N could wrap around to the length of the data
+	if j.M > math.MaxInt/j.N {
+		return fmt.Errorf("matrix dimensions %dx%d are too large", j.M, j.N)
+	}
+	if len(j.Data) != j.M*j.N {
+		return fmt.Errorf("matrix data length %d does not match dimensions %dx%d", len(j.Data), j.M, j.N)
+	}
+
+	m.M = j.M
+	m.N = j.N
+	m.Data = j.Data
+	return nil
+}
@@ -0,0 +1,410 @@
+package mat
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/lattots/gonum/number"
+)
+
+// MAT-file v5 data types (mi*) and array classes (mx*).
+const (
+	miINT8       = 1
+	miUINT8      = 2
+	miINT16      = 3
+	miUINT16     = 4
+	miINT32      = 5
+	miUINT32     = 6
+	miSINGLE     = 7
+	miDOUBLE     = 9
+	miINT64      = 12
+	miUINT64     = 13
+	miMATRIX     = 14
+	miCOMPRESSED = 15
+
+	mxDOUBLE = 6
+	mxSINGLE = 7
+	mxINT8   = 8
+	mxINT16  = 10
+	mxINT32  = 12
+	mxINT64  = 14
+	mxUINT64 = 15
+
+	matHeaderSize   = 128
+	matFlagComplex  = 0x08
+	matMaxNameBytes = 63
+)
+
+var matNameRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// ReadMAT decodes the numeric arrays stored in a MATLAB Level 5 MAT-file, both uncompressed
+// and zlib-compressed, into matrices keyed by variable name. Values of any numeric class are
+// converted to T, failing if a value can't be represented exactly in an integer T.
+// Variables that aren't numeric, such as cells, structs, strings and sparse arrays, and empty
+// arrays are skipped. Complex arrays and arrays with more than two dimensions are rejected.
+func ReadMAT[T number.Num](r io.Reader) (map[string]*Mat[T], error) {
+	br := bufio.NewReader(r)
+
+	header := make([]byte, matHeaderSize)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("failed to read MAT-file header: %w", err)
+	}
+
+	var order binary.ByteOrder
+	switch string(header[126:128]) {
+	case "IM":
+		order = binary.LittleEndian
+	case "MI":
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("not a MAT-file: bad endian indicator %q", header[126:128])
+	}
+	if version := order.Uint16(header[124:126]); version != 0x0100 {
+		return nil, fmt.Errorf("unsupported MAT-file version %#04x", version)
+	}
+
+	vars := make(map[string]*Mat[T])
+	for {
+		var tag [8]byte
+		if _, err := io.ReadFull(br, tag[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return vars, nil
+			}
+			return nil, fmt.Errorf("failed to read MAT-file data element: %w", err)
+		}
+
+		miType, size := order.Uint32(tag[:4]), order.Uint32(tag[4:])
+		payload, err := readExactly(br, int64(size))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read MAT-file data element: %w", err)
+		}
+
+		switch miType {
+		case miCOMPRESSED:
+			// A compressed element holds a complete, uncompressed data element.
+			zr, err := zlib.NewReader(bytes.NewReader(payload))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decompress MAT-file data element: %w", err)
+			}
+			var innerTag [8]byte
+			if _, err := io.ReadFull(zr, innerTag[:]); err != nil {
+				return nil, fmt.Errorf("compressed MAT-file data element too short: %w", err)
+			}
+			miType, size = order.Uint32(innerTag[:4]), order.Uint32(innerTag[4:])
+
+			// Only the declared size is decompressed, so a small crafted stream can't expand without limit
+			payload, err = readExactly(zr, int64(size))
+			if err != nil {
+				return nil, fmt.Errorf("compressed MAT-file data element truncated: %w", err)
+			}
+			rest, err := io.ReadAll(io.LimitReader(zr, 8))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decompress MAT-file data element: %w", err)
+			}
+			if len(rest) > padding8(int(size)) {
+				return nil, fmt.Errorf("compressed MAT-file data element is longer than its declared size %d", size)
+			}
+		default:
+			// Uncompressed elements are padded to a multiple of 8 bytes.
+			if pad := padding8(int(size)); pad > 0 {
+				if _, err := br.Discard(pad); err != nil && !errors.Is(err, io.EOF) {
+					return nil, err
+				}
+			}
+		}
+
+		if miType != miMATRIX {
+			continue
+		}
+
+		name, m, err := decodeMATArray[T](payload, order)
+		if err != nil {
+			return nil, err
+		}
+		if m != nil {
+			vars[name] = m
+		}
+	}
+}
+
+// decodeMATArray decodes the payload of a miMATRIX element. It returns a nil matrix
+// for arrays that are skipped.
+func decodeMATArray[T number.Num](b []byte, order binary.ByteOrder) (string, *Mat[T], error) {
+	sub := matSubelements{b: b, order: order}
+
+	_, flags, err := sub.next()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read MAT-file array flags: %w", err)
+	}
+	if len(flags) < 8 {
+		return "", nil, fmt.Errorf("malformed MAT-file array flags")
+	}
+	word := order.Uint32(flags)
+	class := word & 0xFF
+
+	_, dimBytes, err := sub.next()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read MAT-file array dimensions: %w", err)
+	}
+	dims := make([]int, len(dimBytes)/4)
+	for i := range dims {
+		dims[i] = int(int32(order.Uint32(dimBytes[i*4:])))
+	}
+
+	_, nameBytes, err := sub.next()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read MAT-file array name: %w", err)
+	}
+	name := string(nameBytes)
+
+	if class < mxDOUBLE || class > mxUINT64 {
+		return name, nil, nil
+	}
+	if word&(matFlagComplex<<8) != 0 {
+		return "", nil, fmt.Errorf("MAT-file variable %q is complex, which is not supported", name)
+	}
+	if len(dims) != 2 {
+		return "", nil, fmt.Errorf("MAT-file variable %q has %d dimensions, only 2 are supported", name, len(dims))
+	}
+	rows, cols := dims[0], dims[1]
+	if rows <= 0 || cols <= 0 {
+		return name, nil, nil
+	}
+
+	dataType, raw, err := sub.next()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read MAT-file variable %q: %w", name, err)
+	}
+
+	// Every element takes at least a byte, which bounds the allocation for corrupt dimensions
+	if int64(rows)*int64(cols) > int64(len(raw)) {
+		return "", nil, fmt.Errorf("MAT-file variable %q has %dx%d elements but only %d bytes of data", name, rows, cols, len(raw))
+	}
+
+	// MATLAB stores arrays column by column, which is the row-major layout of the transpose.
+	data := make([]T, rows*cols)
+	if err := decodeMATData(raw, order, dataType, data); err != nil {
+		return "", nil, fmt.Errorf("failed to read MAT-file variable %q: %w", name, err)
+	}
+
+	return name, Transpose(&Mat[T]{M: cols, N: rows, Data: data}), nil
+}
+
+// matSubelements iterates over the data elements packed inside a miMATRIX payload.
+type matSubelements struct {
+	b     []byte
+	order binary.ByteOrder
+}
+
+func (s *matSubelements) next() (uint32, []byte, error) {
+	if len(s.b) < 8 {
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+
+	word := s.order.Uint32(s.b)
+	// In the small element format the size is in the upper 16 bits and the data
+	// fits in the remaining 4 bytes of the tag.
+	if small := word >> 16; small != 0 {
+		if small > 4 {
+			return 0, nil, fmt.Errorf("malformed small data element")
+		}
+		data := s.b[4 : 4+small]
+		s.b = s.b[8:]
+		return word & 0xFFFF, data, nil
+	}
+
+	size := int(s.order.Uint32(s.b[4:]))
+	if size > len(s.b)-8 {
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+	data := s.b[8 : 8+size]
+	s.b = s.b[min(len(s.b), 8+size+padding8(size)):]
+	return word, data, nil
+}
+
+// decodeMATData converts raw elements of the given MAT-file type into data.
+func decodeMATData[T number.Num](b []byte, order binary.ByteOrder, miType uint32, data []T) error {
+	var size int
+	switch miType {
+	case miINT8, miUINT8:
+		size = 1
+	case miINT16, miUINT16:
+		size = 2
+	case miINT32, miUINT32, miSINGLE:
+		size = 4
+	case miDOUBLE, miINT64, miUINT64:
+		size = 8
+	default:
+		return fmt.Errorf("unsupported MAT-file data type %d", miType)
+	}
+	if len(b) != len(data)*size {
+		return fmt.Errorf("expected %d bytes of data, got %d", len(data)*size, len(b))
+	}
+
+	for i := range data {
+		e := b[i*size:]
+
+		var f float64
+		var n int64
+		isInt := true
+		switch miType {
+		case miINT8:
+			n = int64(int8(e[0]))
+		case miUINT8:
+			n = int64(e[0])
+		case miINT16:
+			n = int64(int16(order.Uint16(e)))
+		case miUINT16:
+			n = int64(order.Uint16(e))
+		case miINT32:
+			n = int64(int32(order.Uint32(e)))
+		case miUINT32:
+			n = int64(order.Uint32(e))
+		case miINT64:
+			n = int64(order.Uint64(e))
+		case miUINT64:
+			u := order.Uint64(e)
+			if u > math.MaxInt64 {
+				return fmt.Errorf("value %d at index %d out of range", u, i)
+			}
+			n = int64(u)
+		case miSINGLE:
+			f, isInt = float64(math.Float32frombits(order.Uint32(e))), false
+		case miDOUBLE:
+			f, isInt = math.Float64frombits(order.Uint64(e)), false
+		}
+
+		switch {
+		case isFloat[T]() && isInt:
+			data[i] = T(n)
+		case isFloat[T]():
+			data[i] = T(f)
+		case isInt:
+			if int64(T(n)) != n {
+				return fmt.Errorf("value %d at index %d out of range for %T", n, i, *new(T))
+			}
+			data[i] = T(n)
+		default:
+			if math.Trunc(f) != f || float64(T(f)) != f {
+				return fmt.Errorf("value %g at index %d can't be represented exactly as %T", f, i, *new(T))
+			}
+			data[i] = T(f)
+		}
+	}
+
+	return nil
+}
+
+// WriteMAT encodes the named matrices as a MATLAB Level 5 MAT-file. The MATLAB class follows T:
+// double, single, int8, int16, int32, or int64 for both int64 and int. With compress set every
+// variable is stored zlib-compressed, as MATLAB does by default since version 7.
+// Names must be valid MATLAB identifiers of at most 63 characters.
+func WriteMAT[T number.Num](w io.Writer, vars map[string]*Mat[T], compress bool) error {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		if len(name) > matMaxNameBytes || !matNameRe.MatchString(name) {
+			return fmt.Errorf("invalid MATLAB variable name %q", name)
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	bw := bufio.NewWriter(w)
+
+	header := make([]byte, matHeaderSize)
+	text := fmt.Sprintf("MATLAB 5.0 MAT-file, Platform: %s, Created on: %s", runtime.GOOS, time.Now().Format("Mon Jan 2 15:04:05 2006"))
+	copy(header, bytes.Repeat([]byte{' '}, 116))
+	copy(header, text)
+	binary.LittleEndian.PutUint16(header[124:], 0x0100)
+	copy(header[126:], "IM")
+	bw.Write(header)
+
+	for _, name := range names {
+		element := encodeMATArray(name, vars[name])
+
+		if compress {
+			var buf bytes.Buffer
+			zw := zlib.NewWriter(&buf)
+			zw.Write(element)
+			if err := zw.Close(); err != nil {
+				return fmt.Errorf("failed to compress MAT-file variable %q: %w", name, err)
+			}
+			element = appendMATElement(nil, miCOMPRESSED, buf.Bytes(), false)
+		}
+
+		if _, err := bw.Write(element); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// encodeMATArray encodes m as a complete miMATRIX data element.
+func encodeMATArray[T number.Num](name string, m *Mat[T]) []byte {
+	class, miType := matClass[T]()
+
+	flags := make([]byte, 8)
+	binary.LittleEndian.PutUint32(flags, class)
+
+	dims := make([]byte, 8)
+	binary.LittleEndian.PutUint32(dims, uint32(m.M))
+	binary.LittleEndian.PutUint32(dims[4:], uint32(m.N))
+
+	// MATLAB stores arrays column by column.
+	columnMajor := Transpose(m)
+	data := make([]byte, len(m.Data)*binaryElemSize[T]())
+	encodeBinaryData(data, binary.LittleEndian, columnMajor.Data)
+
+	var payload []byte
+	payload = appendMATElement(payload, miUINT32, flags, true)
+	payload = appendMATElement(payload, miINT32, dims, true)
+	payload = appendMATElement(payload, miINT8, []byte(name), true)
+	payload = appendMATElement(payload, miType, data, true)
+
+	return appendMATElement(nil, miMATRIX, payload, true)
+}
+
+// matClass returns the MATLAB array class and data type used to store T.
+func matClass[T number.Num]() (class uint32, miType uint32) {
+	switch binaryTag[T]() {
+	case tagInt8:
+		return mxINT8, miINT8
+	case tagInt16:
+		return mxINT16, miINT16
+	case tagInt32:
+		return mxINT32, miINT32
+	case tagInt64, tagInt:
+		return mxINT64, miINT64
+	case tagFloat32:
+		return mxSINGLE, miSINGLE
+	default:
+		return mxDOUBLE, miDOUBLE
+	}
+}
+
+// appendMATElement appends a data element with the given type and data to b,
+// padding it to a multiple of 8 bytes if pad is set.
+func appendMATElement(b []byte, miType uint32, data []byte, pad bool) []byte {
+	b = binary.LittleEndian.AppendUint32(b, miType)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(data)))
+	b = append(b, data...)
+	if pad {
+		b = append(b, make([]byte, padding8(len(data)))...)
+	}
+	return b
+}
+
+func padding8(n int) int {
+	return (8 - n%8) % 8
+}
@@ -0,0 +1,331 @@
+package mat
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/lattots/gonum/number"
+)
+
+// The matrix functions work in float64 and round the result to T once at the end.
+
+// errNoRealSqrt is returned when the square root iteration breaks down or doesn't converge.
+var errNoRealSqrt = errors.New("matrix math error: square root iteration did not converge, the matrix may have negative real eigenvalues")
+
+// Expm calculates the matrix exponential e^a with the scaling and squaring method of
+// Higham (2005): a is scaled by a power of two until a Padé approximant of degree up to
+// 13 is accurate to double precision, and the result is squared back. Returns an error
+// if a is not square or has non-finite elements.
+func Expm[T number.Float](a *Mat[T]) (*Mat[T], error) {
+	if err := checkSquare("Expm", a); err != nil {
+		return nil, err
+	}
+	x := convert[float64](a)
+	norm := norm1(x)
+	if math.IsNaN(norm) || math.IsInf(norm, 0) {
+		return nil, fmt.Errorf("matrix math error: cannot calculate the exponential of a matrix with non-finite elements")
+	}
+
+	// Use the lowest degree that is accurate enough without scaling
+	for i, theta := range padeTheta[:len(padeTheta)-1] {
+		if norm <= theta {
+			u, v := padeTerms(x, padeCoefs[i])
+			return convert[T](padeQuotient(u, v)), nil
+		}
+	}
+
+	s := 0
+	if norm > padeTheta[len(padeTheta)-1] {
+		s = int(math.Ceil(math.Log2(norm / padeTheta[len(padeTheta)-1])))
+		x = Scale(x, math.Ldexp(1, -s))
+	}
+	r := padeQuotient(pade13(x))
+	for range s {
+		r = dot(r, r)
+	}
+	return convert[T](r), nil
+}
+
+// padeTheta are the largest 1-norms for which the Padé approximants of degree 3, 5, 7,
+// 9 and 13 are accurate to double precision, from Higham (2005), table 2.3.
+var padeTheta = []float64{1.495585217958292e-2, 2.539398330063230e-1, 9.504178996162932e-1, 2.097847961257068, 5.371920351148152}
+
+// padeCoefs are the coefficients of the numerators of the Padé approximants in padeTheta.
+var padeCoefs = [][]float64{
+	{120, 60, 12, 1},
+	{30240, 15120, 3360, 420, 30, 1},
+	{17297280, 8648640, 1995840, 277200, 25200, 1512, 56, 1},
+	{17643225600, 8821612800, 2075673600, 302702400, 30270240, 2162160, 110880, 3960, 90, 1},
+	{
+		64764752532480000, 32382376266240000, 7771770303897600, 1187353796428800, 129060195264000,
+		10559470521600, 670442572800, 33522128640, 1323241920, 40840800, 960960, 16380, 182, 1,
+	},
+}
+
+// padeTerms returns the odd part u and the even part v of the numerator of a Padé
+// approximant of degree up to 9, whose denominator is v - u.
+func padeTerms(x *Mat[float64], b []float64) (u, v *Mat[float64]) {
+	n := x.M
+	x2 := dot(x, x)
+
+	v = Scale(identity[float64](n), b[0])
+	u = Scale(identity[float64](n), b[1])
+	p := identity[float64](n)
+	for k := 2; k < len(b); k += 2 {
+		p = dot(p, x2)
+		addScaled(v, b[k], p)
+		addScaled(u, b[k+1], p)
+	}
+	return dot(x, u), v
+}
+
+// pade13 returns the terms of the degree 13 approximant, evaluated with fewer products
+// by reusing x⁶.
+func pade13(x *Mat[float64]) (u, v *Mat[float64]) {
+	b := padeCoefs[len(padeCoefs)-1]
+	n := x.M
+	x2 := dot(x, x)
+	x4 := dot(x2, x2)
+	x6 := dot(x4, x2)
+
+	inner := Scale(x6, b[13])
+	addScaled(inner, b[11], x4)
+	addScaled(inner, b[9], x2)
+	u = dot(x6, inner)
+	addScaled(u, b[7], x6)
+	addScaled(u, b[5], x4)
+	addScaled(u, b[3], x2)
+	addScaled(u, b[1], identity[float64](n))
+	u = dot(x, u)
+
+	inner = Scale(x6, b[12])
+	addScaled(inner, b[10], x4)
+	addScaled(inner, b[8], x2)
+	v = dot(x6, inner)
+	addScaled(v, b[6], x6)
+	addScaled(v, b[4], x4)
+	addScaled(v, b[2], x2)
+	addScaled(v, b[0], identity[float64](n))
+	return u, v
+}
+
+// padeQuotient solves (v - u) r = v + u for the value r of the approximant.
+func padeQuotient(u, v *Mat[float64]) *Mat[float64] {
+	// The denominator is close to the identity for the norms allowed by padeTheta
+	f, err := luFactor(Subtract(v, u))
+	if err != nil {
+		panic("matrix math error: singular Padé denominator")
+	}
+	return f.solve(Sum(v, u))
+}
+
+// Sqrtm calculates the principal square root of a, the matrix X with X X = a whose
+// eigenvalues have positive real parts. It uses the Denman-Beavers iteration with
+// determinant scaling. Returns ErrSingular if a is singular, and an error if the
+// iteration doesn't converge, as happens for matrices with negative real eigenvalues
+// that have no real principal square root.
+func Sqrtm[T number.Float](a *Mat[T]) (*Mat[T], error) {
+	if err := checkSquare("Sqrtm", a); err != nil {
+		return nil, err
+	}
+	if _, err := luFactor(convert[float64](a)); err != nil {
+		return nil, err
+	}
+
+	r, err := sqrtm(convert[float64](a))
+	if err != nil {
+		return nil, err
+	}
+	return convert[T](r), nil
+}
+
+func sqrtm(a *Mat[float64]) (*Mat[float64], error) {
+	const maxIter = 100
+	n := a.M
+	tol := math.Sqrt(0x1p-52)
+
+	y, z := a, identity[float64](n)
+	converged := false
+	for range maxIter {
+		fy, err := luFactor(y)
+		if err != nil {
+			return nil, errNoRealSqrt
+		}
+		fz, err := luFactor(z)
+		if err != nil {
+			return nil, errNoRealSqrt
+		}
+
+		// Scaling by the determinants speeds up the early iterations, and is turned
+		// off near convergence so the final steps converge quadratically
+		mu := 1.0
+		if !converged {
+			mu = math.Exp(-(fy.logAbsDet() + fz.logAbsDet()) / float64(2*n))
+		}
+
+		yNext := Scale(y, mu/2)
+		addScaled(yNext, 1/(2*mu), fz.inverse())
+		zNext := Scale(z, mu/2)
+		addScaled(zNext, 1/(2*mu), fy.inverse())
+
+		// One more step after the change falls below the square root of the machine
+		// epsilon brings it down to the order of the epsilon
+		if converged {
+			return yNext, nil
+		}
+		change := norm1(Subtract(yNext, y)) / norm1(yNext)
+		if math.IsNaN(change) || math.IsInf(change, 0) {
+			return nil, errNoRealSqrt
+		}
+		converged = change <= tol
+		y, z = yNext, zNext
+	}
+
+	return nil, errNoRealSqrt
+}
+
+// Logm calculates the principal logarithm of a, the matrix X with e^X = a whose
+// eigenvalues have imaginary parts in (-π, π). It uses inverse scaling and squaring:
+// square roots are taken until a is close to the identity, where log(I + E) is
+// approximated with a Padé approximant evaluated by Gauss-Legendre quadrature, and the
+// result is scaled back. Returns ErrSingular if a is singular, and an error if a has
+// negative real eigenvalues, which have no real logarithm.
+func Logm[T number.Float](a *Mat[T]) (*Mat[T], error) {
+	if err := checkSquare("Logm", a); err != nil {
+		return nil, err
+	}
+	x := convert[float64](a)
+	if _, err := luFactor(x); err != nil {
+		return nil, err
+	}
+
+	n := a.M
+	ident := identity[float64](n)
+	k := 0
+	for norm1(Subtract(x, ident)) > 0.25 {
+		// Every square root halves the arguments of the eigenvalues, so many roots
+		// mean the iteration isn't getting anywhere
+		if k == 64 {
+			return nil, errNoRealSqrt
+		}
+		var err error
+		if x, err = sqrtm(x); err != nil {
+			return nil, err
+		}
+		k++
+	}
+
+	// log(I + E) = ∫₀¹ E (I + tE)⁻¹ dt, where m-point Gauss-Legendre quadrature gives
+	// the [m/m] Padé approximant, accurate to double precision for ‖E‖₁ <= 0.25 with m = 8
+	e := Subtract(x, ident)
+	res, _ := Zeros[float64](n, n)
+	nodes, weights := gaussLegendre(8)
+	for j, t := range nodes {
+		denom := Sum(ident, Scale(e, t))
+		f, err := luFactor(denom)
+		if err != nil {
+			return nil, err
+		}
+		addScaled(res, weights[j], f.solve(e))
+	}
+
+	return convert[T](Scale(res, math.Ldexp(1, k))), nil
+}
+
+// gaussLegendre returns the nodes and weights of m-point Gauss-Legendre quadrature on [0, 1].
+func gaussLegendre(m int) (nodes, weights []float64) {
+	nodes, weights = make([]float64, m), make([]float64, m)
+	for i := range m {
+		// Newton's method on the Legendre polynomial Pₘ from the Chebyshev approximation of the root
+		x := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(m) + 0.5))
+		var dp float64
+		for range 100 {
+			p0, p1 := 1.0, x
+			for k := 2; k <= m; k++ {
+				p0, p1 = p1, ((2*float64(k)-1)*x*p1-(float64(k)-1)*p0)/float64(k)
+			}
+			dp = float64(m) * (x*p1 - p0) / (x*x - 1)
+			dx := p1 / dp
+			x -= dx
+			if math.Abs(dx) < 1e-16 {
+				break
+			}
+		}
+		nodes[i] = (1 - x) / 2
+		weights[i] = 1 / ((1 - x*x) * dp * dp)
+	}
+	return nodes, weights
+}
+
+// Pow calculates a to the power k by repeated squaring, which takes about 2 log₂(k)
+// products with Dot. Pow(a, 0) is the identity. Returns an error if a is not square
+// or k is negative.
+func Pow[T number.Num](a *Mat[T], k int) (*Mat[T], error) {
+	if err := checkSquare("Pow", a); err != nil {
+		return nil, err
+	}
+	if k < 0 {
+		return nil, fmt.Errorf("matrix math error: cannot raise a matrix to the negative power %d", k)
+	}
+
+	var res *Mat[T]
+	base := a
+	for k > 0 {
+		if k&1 == 1 {
+			if res == nil {
+				res = base
+			} else {
+				res = dot(res, base)
+			}
+		}
+		k >>= 1
+		if k > 0 {
+			base = dot(base, base)
+		}
+	}
+
+	if res == nil {
+		return identity[T](a.M), nil
+	}
+	if res == a {
+		res = Map(a, func(v T) T { return v })
+	}
+	return res, nil
+}
+
+func checkSquare[T number.Num](op string, a *Mat[T]) error {
+	if a.M != a.N {
+		return shapeErrorf(op, "need a square matrix, got %dx%d", a.M, a.N)
+	}
+	return nil
+}
+
+// dot multiplies matrices whose shapes are known to match.
+func dot[T number.Num](a, b *Mat[T]) *Mat[T] {
+	res, _ := Dot(a, b)
+	return res
+}
+
+// addScaled adds alpha*x to dst in place.
+func addScaled(dst *Mat[float64], alpha float64, x *Mat[float64]) {
+	for i, v := range x.Data {
+		dst.Data[i] += alpha * v
+	}
+}
+
+// norm1 calculates the 1-norm of m, its largest absolute column sum.
+func norm1(m *Mat[float64]) float64 {
+	sums := make([]float64, m.N)
+	for i, v := range m.Data {
+		sums[i%m.N] += math.Abs(v)
+	}
+	var res float64
+	for _, s := range sums {
+		// Keep NaN so that callers can detect non-finite input
+		if s > res || math.IsNaN(s) {
+			res = s
+		}
+	}
+	return res
+}
@@ -2,11 +2,54 @@ package mat
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/lattots/gonum/number"
 )
 
+// Matrix is the read access shared by all matrix types: Mat, the structured types
+// TriDense, SymDense, DiagDense and BandDense, the sparse CSR, and matrices defined
+// outside this package. Functions such as Dot, Sum and Transpose accept any Matrix and always
+// return a new Mat.
+type Matrix[T number.Num] interface {
+	// Dims returns the number of rows and columns.
+	Dims() (rows, cols int)
+	// At returns the element at the 1-based row i and column j.
+	At(i, j int) T
+}
+
+// RawMatrixer is implemented by matrices stored as a dense row-major slice. Functions
+// that accept a Matrix work on the storage of a RawMatrixer directly instead of reading
+// it element by element through At.
+type RawMatrixer[T number.Num] interface {
+	Matrix[T]
+	// RawMatrix returns the matrix as a Mat sharing its storage.
+	RawMatrix() *Mat[T]
+}
+
+// DenseOf returns m as a Mat. A RawMatrixer such as a Mat is returned as is, sharing
+// its storage, and any other Matrix is copied element by element.
+func DenseOf[T number.Num](m Matrix[T]) *Mat[T] {
+	switch m := m.(type) {
+	case RawMatrixer[T]:
+		return m.RawMatrix()
+	case Transposed[T]:
+		return Transpose(m.m)
+	}
+	return toDense(m)
+}
+
+// toDense copies any matrix into a new Mat.
+func toDense[T number.Num](m Matrix[T]) *Mat[T] {
+	rows, cols := m.Dims()
+	d := &Mat[T]{M: rows, N: cols, Data: make([]T, rows*cols)}
+	for i := range rows {
+		for j := range cols {
+			d.Data[i*cols+j] = m.At(i+1, j+1)
+		}
+	}
+	return d
+}
+
 type Mat[T number.Num] struct {
 	M    int
 	N    int
@@ -67,11 +110,29 @@ func Ones[T number.Num](m, n int) (*Mat[T], error) {
 	}, nil
 }
 
+// Dims returns the number of rows and columns of m.
+func (m *Mat[T]) Dims() (rows, cols int) {
+	return m.M, m.N
+}
+
+// RawMatrix returns m itself, see RawMatrixer.
+func (m *Mat[T]) RawMatrix() *Mat[T] {
+	return m
+}
+
 func (m *Mat[T]) At(i, j int) T {
 	return m.Data[(i-1)*m.N+(j-1)]
 }
 
-func Transpose[T number.Num](m *Mat[T]) *Mat[T] {
+// Transpose returns a copy of a with rows and columns swapped. Use T for a transpose
+// that doesn't copy.
+func Transpose[T number.Num](a Matrix[T]) *Mat[T] {
+	if t, ok := a.(Transposed[T]); ok {
+		m := DenseOf(t.m)
+		return &Mat[T]{M: m.M, N: m.N, Data: append([]T(nil), m.Data...)}
+	}
+
+	m := DenseOf(a)
 	newData := make([]T, len(m.Data))
 
 	// Map old indices to transposed indices in the new matrix
@@ -90,61 +151,14 @@ func Transpose[T number.Num](m *Mat[T]) *Mat[T] {
 	}
 }
 
-func T[T number.Num](m *Mat[T]) *Mat[T] {
-	return Transpose(m)
-}
-
+// String returns a summary of m with the dimensions and at most three rows and
+// columns. Use the %+v verb or Formatted to print more.
 func (m *Mat[T]) String() string {
-	var sb strings.Builder
-
-	sb.WriteString(fmt.Sprintf("%d x %d\n", m.M, m.N))
-
-	// Indices of the printed rows
-	var rowsToPrint []int
-	if m.M <= 3 {
-		for i := 0; i < m.M; i++ {
-			rowsToPrint = append(rowsToPrint, i)
-		}
-	} else {
-		// -1 represents a marker
-		rowsToPrint = []int{0, 1, -1, m.M - 1}
-	}
-
-	for _, r := range rowsToPrint {
-		// Handle marker
-		if r == -1 {
-			sb.WriteString("...\n")
-			continue
-		}
-
-		var rowElements []string
-
-		if m.N <= 3 {
-			for c := 0; c < m.N; c++ {
-				idx := r*m.N + c
-				rowElements = append(rowElements, formatElement(m.Data[idx]))
-			}
-			sb.WriteString(strings.Join(rowElements, " ") + "\n")
-		} else {
-			idx0 := r*m.N + 0
-			idx1 := r*m.N + 1
-			idxLast := r*m.N + (m.N - 1)
-
-			rowElements = append(
-				rowElements,
-				formatElement(m.Data[idx0]),
-				formatElement(m.Data[idx1]),
-				"...",
-				formatElement(m.Data[idxLast]),
-			)
-			sb.WriteString(strings.Join(rowElements, " ") + "\n")
-		}
-	}
-
-	return sb.String()
+	return renderMatrix(m, formatElement[T], true, FormatOptions{}, AlignNone)
 }
 
-func Scale[T number.Num](m *Mat[T], scalar T) *Mat[T] {
+func Scale[T number.Num](a Matrix[T], scalar T) *Mat[T] {
+	m := DenseOf(a)
 	data := make([]T, len(m.Data))
 	for i := range m.Data {
 		data[i] = m.Data[i] * scalar
@@ -156,7 +170,8 @@ func Scale[T number.Num](m *Mat[T], scalar T) *Mat[T] {
 	}
 }
 
-func Add[T number.Num](m *Mat[T], scalar T) *Mat[T] {
+func Add[T number.Num](a Matrix[T], scalar T) *Mat[T] {
+	m := DenseOf(a)
 	data := make([]T, len(m.Data))
 	for i := range m.Data {
 		data[i] = m.Data[i] + scalar
@@ -168,7 +183,8 @@ func Add[T number.Num](m *Mat[T], scalar T) *Mat[T] {
 	}
 }
 
-func Map[T number.Num](m *Mat[T], fn func(T) T) *Mat[T] {
+func Map[T number.Num](a Matrix[T], fn func(T) T) *Mat[T] {
+	m := DenseOf(a)
 	data := make([]T, len(m.Data))
 	for i := range m.Data {
 		data[i] = fn(m.Data[i])
@@ -181,7 +197,8 @@ func Map[T number.Num](m *Mat[T], fn func(T) T) *Mat[T] {
 	}
 }
 
-func Min[T number.Num](m *Mat[T]) T {
+func Min[T number.Num](a Matrix[T]) T {
+	m := DenseOf(a)
 	if len(m.Data) == 0 {
 		panic("matrix math error: cannot find minimum of an empty matrix")
 	}
@@ -196,7 +213,8 @@ func Min[T number.Num](m *Mat[T]) T {
 	return curMin
 }
 
-func Max[T number.Num](m *Mat[T]) T {
+func Max[T number.Num](a Matrix[T]) T {
+	m := DenseOf(a)
 	if len(m.Data) == 0 {
 		panic("matrix math error: cannot find maximum of an empty matrix")
 	}
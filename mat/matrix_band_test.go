@@ -0,0 +1,144 @@
+package mat_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+// randomBand returns a random n x n band matrix, with a dominant diagonal if dominant is set.
+func randomBand(rng *rand.Rand, n, kl, ku int, dominant bool) *mat.BandDense[float64] {
+	b, _ := mat.NewBandDense[float64](n, kl, ku)
+	for i := 1; i <= n; i++ {
+		for j := max(1, i-kl); j <= min(n, i+ku); j++ {
+			v := 2*rng.Float64() - 1
+			if i == j && dominant {
+				v += float64(kl + ku + 1)
+			}
+			b.SetBand(i, j, v)
+		}
+	}
+	return b
+}
+
+func TestBandDense(t *testing.T) {
+	start := time.Now()
+
+	rng := rand.New(rand.NewSource(1))
+	b := randomBand(rng, 7, 2, 1, false)
+
+	// Test case 1: Round trip through a dense matrix
+	dense := b.Dense()
+	if dense.At(1, 4) != 0 || dense.At(5, 3) != b.At(5, 3) {
+		t.Errorf("Wrong dense copy: %+v", dense)
+	}
+	back, err := mat.BandDenseFrom(dense, 2, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !mattest.EqualMatrix(back.Dense(), dense) {
+		t.Errorf("Wrong round trip. Want: %s\nGot: %s", dense, back.Dense())
+	}
+
+	// Test case 2: Elements outside the band are rejected
+	if _, err := mat.BandDenseFrom(dense, 1, 1); err == nil {
+		t.Errorf("Expected an error for elements outside the band")
+	}
+	if _, err := mat.NewBandDense[float64](3, 3, 0); err == nil {
+		t.Errorf("Expected an error for a bandwidth as large as the matrix")
+	}
+	expectPanic(t, "SetBand outside the band", func() { b.SetBand(1, 3, 1) })
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestSolveTridiag(t *testing.T) {
+	start := time.Now()
+
+	// Test case 1: The 1D Poisson problem -u'' = 2 on [0, 1] with u(0) = u(1) = 0 has
+	// the solution u = x(1-x), which the second order difference scheme reproduces exactly
+	n := 99
+	h := 1.0 / float64(n+1)
+	a, _ := mat.NewBandDense[float64](n, 1, 1)
+	rhs, _ := mat.Zeros[float64](n, 1)
+	want, _ := mat.Zeros[float64](n, 1)
+	for i := 1; i <= n; i++ {
+		a.SetBand(i, i, 2)
+		if i > 1 {
+			a.SetBand(i, i-1, -1)
+		}
+		if i < n {
+			a.SetBand(i, i+1, -1)
+		}
+		x := float64(i) * h
+		rhs.Data[i-1] = 2 * h * h
+		want.Data[i-1] = x * (1 - x)
+	}
+
+	got, err := mat.SolveTridiag(a, rhs)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	mattest.AssertEqual(t, got, want, mattest.Tolerance{Abs: 1e-12})
+
+	// Test case 2: Several right-hand sides at once agree with banded LU
+	rng := rand.New(rand.NewSource(2))
+	a = randomBand(rng, 20, 1, 1, true)
+	b := mattest.Random[float64](rng, 20, 3)
+	got, _ = mat.SolveTridiag(a, b)
+	wantLU, _ := mat.SolveBand(a, b)
+	mattest.AssertEqual(t, got, wantLU, mattest.Tolerance{Abs: 1e-12})
+
+	// Test case 3: Wider bands and zero pivots are rejected
+	if _, err := mat.SolveTridiag(randomBand(rng, 5, 2, 1, true), b); err == nil {
+		t.Errorf("Expected an error for a matrix that isn't tridiagonal")
+	}
+	zero, _ := mat.NewBandDense[float64](3, 1, 1)
+	if _, err := mat.SolveTridiag(zero, mattest.Random[float64](rng, 3, 1)); !errors.Is(err, mat.ErrSingular) {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestBandLU(t *testing.T) {
+	start := time.Now()
+
+	rng := rand.New(rand.NewSource(3))
+
+	for _, bw := range [][2]int{{0, 0}, {1, 2}, {3, 1}, {2, 2}} {
+		// Test case 1: Without a dominant diagonal the factorization has to pivot
+		a := randomBand(rng, 40, bw[0], bw[1], false)
+		x := mattest.Random[float64](rng, 40, 2)
+		b, _ := mat.Dot(a.Dense(), x)
+
+		f, err := mat.FactorBand(a)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		got, err := f.Solve(b)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !mattest.AssertEqual(t, got, x, mattest.Tolerance{Abs: 1e-8, Rel: 1e-8}) {
+			t.Errorf("Wrong solution for bandwidths %v", bw)
+		}
+	}
+
+	// Test case 2: Singular matrices and mismatched right-hand sides
+	singular, _ := mat.NewBandDense[float64](4, 1, 1)
+	if _, err := mat.FactorBand(singular); !errors.Is(err, mat.ErrSingular) {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+	f, _ := mat.FactorBand(randomBand(rng, 4, 1, 1, true))
+	if _, err := f.Solve(mattest.Random[float64](rng, 3, 1)); err == nil {
+		t.Errorf("Expected an error for a right-hand side with the wrong number of rows")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
@@ -0,0 +1,182 @@
+package mat
+
+// The benchmarks live in package mat so that the naive and Strassen multiplications
+// can be compared directly at the same size. Sub-benchmarks are named
+// <operation>/<type>/<size> so that results can be tracked with cmd/gonum-bench.
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/lattots/gonum/number"
+)
+
+// typeNames are the names of the sub-benchmarks passed to runTypes, in order.
+var typeNames = []string{"int", "int8", "int16", "int32", "int64", "float32", "float64"}
+
+// runTypes runs one instantiation of a generic benchmark for every number.Num type.
+func runTypes(b *testing.B, fns ...func(*testing.B)) {
+	for i, fn := range fns {
+		b.Run(typeNames[i], fn)
+	}
+}
+
+// dotSizes straddle naiveThreshold so the crossover can be read off the results. The
+// strassen sub-benchmarks exclude padding, which Dot adds on top of them.
+var dotSizes = []int{64, 96, 128, 192, 256, 384, 512}
+
+// elementSizes are the edge lengths of the square matrices for the other benchmarks.
+var elementSizes = []int{64, 512}
+
+func benchMatrix[T number.Num](n int, seed int64) *Mat[T] {
+	rng := rand.New(rand.NewSource(seed))
+	data := make([]T, n*n)
+	for i := range data {
+		data[i] = T(rng.Intn(7) - 3)
+	}
+	return &Mat[T]{M: n, N: n, Data: data}
+}
+
+func BenchmarkDot(b *testing.B) {
+	runTypes(b, benchDot[int], benchDot[int8], benchDot[int16], benchDot[int32], benchDot[int64], benchDot[float32], benchDot[float64])
+}
+
+func benchDot[T number.Num](b *testing.B) {
+	for _, n := range dotSizes {
+		m1, m2 := benchMatrix[T](n, 1), benchMatrix[T](n, 2)
+
+		b.Run(fmt.Sprintf("naive/%d", n), func(b *testing.B) {
+			for range b.N {
+				dotNaive(m1, m2)
+			}
+		})
+		// Padding is done once outside the timed loop, and the recursion always goes at
+		// least one level deep, so sizes below the threshold measure Strassen too
+		sq1, sq2 := Square(m1, m2)
+		threshold := min(StrassenThreshold[T](), sq1.M/2)
+		b.Run(fmt.Sprintf("strassen/%d", n), func(b *testing.B) {
+			for range b.N {
+				dotStrassen(sq1, sq2, threshold)
+			}
+		})
+		b.Run(fmt.Sprintf("auto/%d", n), func(b *testing.B) {
+			for range b.N {
+				Dot(m1, m2)
+			}
+		})
+		b.Run(fmt.Sprintf("transA/%d", n), func(b *testing.B) {
+			for range b.N {
+				DotTrans(true, false, m1, m2)
+			}
+		})
+	}
+}
+
+func BenchmarkTranspose(b *testing.B) {
+	runTypes(b, benchTranspose[int], benchTranspose[int8], benchTranspose[int16], benchTranspose[int32], benchTranspose[int64], benchTranspose[float32], benchTranspose[float64])
+}
+
+func benchTranspose[T number.Num](b *testing.B) {
+	for _, n := range elementSizes {
+		m := benchMatrix[T](n, 1)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for range b.N {
+				Transpose(m)
+			}
+		})
+	}
+}
+
+func BenchmarkElementwise(b *testing.B) {
+	runTypes(b, benchElementwise[int], benchElementwise[int8], benchElementwise[int16], benchElementwise[int32], benchElementwise[int64], benchElementwise[float32], benchElementwise[float64])
+}
+
+func benchElementwise[T number.Num](b *testing.B) {
+	for _, n := range elementSizes {
+		m1, m2 := benchMatrix[T](n, 1), benchMatrix[T](n, 2)
+		row := SliceRows(m2, 0, 1)
+
+		ops := []struct {
+			name string
+			fn   func()
+		}{
+			{"Sum", func() { Sum(m1, m2) }},
+			{"Subtract", func() { Subtract(m1, m2) }},
+			{"Mul", func() { Mul(m1, m2) }},
+			{"MulBroadcast", func() { Mul(m1, row) }},
+			{"Scale", func() { Scale(m1, 3) }},
+			{"Add", func() { Add(m1, 3) }},
+			{"Map", func() { Map(m1, func(v T) T { return v * v }) }},
+		}
+		for _, op := range ops {
+			b.Run(fmt.Sprintf("%s/%d", op.name, n), func(b *testing.B) {
+				for range b.N {
+					op.fn()
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkReductions(b *testing.B) {
+	runTypes(b, benchReductions[int], benchReductions[int8], benchReductions[int16], benchReductions[int32], benchReductions[int64], benchReductions[float32], benchReductions[float64])
+}
+
+func benchReductions[T number.Num](b *testing.B) {
+	for _, n := range elementSizes {
+		m := benchMatrix[T](n, 1)
+
+		for _, axis := range []Axis{AxisAll, AxisRows, AxisCols} {
+			ops := []struct {
+				name string
+				fn   func()
+			}{
+				{"SumAxis", func() { SumAxis(m, axis) }},
+				{"MeanAxis", func() { MeanAxis(m, axis) }},
+				{"VarAxis", func() { VarAxis(m, axis, 0) }},
+				{"MaxAxis", func() { MaxAxis(m, axis) }},
+			}
+			for _, op := range ops {
+				b.Run(fmt.Sprintf("%s/%s/%d", op.name, axis, n), func(b *testing.B) {
+					for range b.N {
+						op.fn()
+					}
+				})
+			}
+		}
+	}
+}
+
+func BenchmarkSmall(b *testing.B) {
+	for _, n := range []int{3, 4} {
+		m := benchMatrix[float64](n, 1)
+		b.Run(fmt.Sprintf("Dot/%d", n), func(b *testing.B) {
+			for range b.N {
+				Dot(m, m)
+			}
+		})
+	}
+
+	// Results are stored so the products aren't optimized away
+	m3, _ := Mat3From(benchMatrix[float64](3, 1))
+	var r3 Mat3[float64]
+	b.Run("Mat3.Mul", func(b *testing.B) {
+		for range b.N {
+			r3 = m3.Mul(m3)
+		}
+	})
+	m4, _ := Mat4From(benchMatrix[float64](4, 1))
+	var r4 Mat4[float64]
+	b.Run("Mat4.Mul", func(b *testing.B) {
+		for range b.N {
+			r4 = m4.Mul(m4)
+		}
+	})
+	b.Run("Mat4.Inverse", func(b *testing.B) {
+		for range b.N {
+			r4, _ = m4.Inverse()
+		}
+	})
+	_, _ = r3, r4
+}
@@ -0,0 +1,209 @@
+package mat_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+func TestBroadcastShape(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.Zeros[float64](4, 3)
+	row, _ := mat.Zeros[float64](1, 3)
+	col, _ := mat.Zeros[float64](4, 1)
+	scalar, _ := mat.Zeros[float64](1, 1)
+	bad, _ := mat.Zeros[float64](2, 3)
+
+	testCases := []struct {
+		m1, m2     *mat.Mat[float64]
+		rows, cols int
+	}{
+		{m, row, 4, 3},
+		{row, m, 4, 3},
+		{m, col, 4, 3},
+		{col, row, 4, 3},
+		{scalar, m, 4, 3},
+		{m, m, 4, 3},
+	}
+
+	for i, tc := range testCases {
+		rows, cols, err := mat.BroadcastShape(tc.m1, tc.m2)
+		if err != nil {
+			t.Errorf("Test case %d: unexpected error: %v", i, err)
+		}
+		if rows != tc.rows || cols != tc.cols {
+			t.Errorf("Test case %d: wrong shape. Want: %dx%d, Got: %dx%d", i, tc.rows, tc.cols, rows, cols)
+		}
+	}
+
+	_, _, err := mat.BroadcastShape(m, bad)
+	var shapeErr *mat.ShapeError
+	if !errors.As(err, &shapeErr) {
+		t.Errorf("Expected a ShapeError for incompatible shapes, got %v", err)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestBroadcastArithmetic(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.New([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	row, _ := mat.New([][]float64{{10, 20, 30}})
+	col, _ := mat.New([][]float64{{1}, {2}})
+
+	// Test case 1: Sum with a row vector
+	expected, _ := mat.New([][]float64{
+		{11, 22, 33},
+		{14, 25, 36},
+	})
+	result := mat.Sum(m, row)
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in broadcast Sum. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 2: Subtract a column vector
+	expected, _ = mat.New([][]float64{
+		{0, 1, 2},
+		{2, 3, 4},
+	})
+	result = mat.Subtract(m, col)
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in broadcast Subtract. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 3: Outer product shape from a column and a row
+	expected, _ = mat.New([][]float64{
+		{10, 20, 30},
+		{20, 40, 60},
+	})
+	result, err := mat.Mul(col, row)
+	if err != nil {
+		t.Errorf("Error in broadcast Mul: %v", err)
+	}
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in broadcast Mul. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 4: Division by a column vector
+	expected, _ = mat.New([][]float64{
+		{1, 2, 3},
+		{2, 2.5, 3},
+	})
+	result, err = mat.Div(m, col)
+	if err != nil {
+		t.Errorf("Error in broadcast Div: %v", err)
+	}
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in broadcast Div. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 5: Incompatible shapes (should panic in Sum, error in Mul)
+	bad, _ := mat.New([][]float64{{1, 2}})
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Sum did not panic on incompatible shapes")
+			}
+		}()
+		mat.Sum(m, bad)
+	}()
+	if _, err := mat.Mul(m, bad); err == nil {
+		t.Errorf("Expected error from Mul on incompatible shapes, but got nil")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestBroadcastNormalizeFeatures(t *testing.T) {
+	start := time.Now()
+
+	features, _ := mat.New([][]float64{
+		{1, 10},
+		{2, 20},
+		{3, 30},
+	})
+
+	// Column means and a per-column scale, both 1xN row vectors
+	means := mat.Scale(mat.SumRows(features), 1.0/float64(features.M))
+	scale, _ := mat.New([][]float64{{1, 10}})
+
+	centered := mat.Subtract(features, means)
+	result, err := mat.Div(centered, scale)
+	if err != nil {
+		t.Errorf("Error normalizing features: %v", err)
+	}
+
+	expected, _ := mat.New([][]float64{
+		{-1, -1},
+		{0, 0},
+		{1, 1},
+	})
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result normalizing features. Want: %s\nGot: %s", expected, result)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestMap2AndComparison(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.New([][]int{
+		{1, 5},
+		{3, 2},
+	})
+	threshold, _ := mat.New([][]int{{3}})
+
+	// Test case 1: Comparisons against a 1x1 matrix
+	expected, _ := mat.New([][]int{
+		{0, 1},
+		{0, 0},
+	})
+	result, err := mat.Greater(m, threshold)
+	if err != nil {
+		t.Errorf("Error in Greater: %v", err)
+	}
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in Greater. Want: %s\nGot: %s", expected, result)
+	}
+
+	expected, _ = mat.New([][]int{
+		{1, 0},
+		{1, 1},
+	})
+	result, _ = mat.LessEqual(m, threshold)
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in LessEqual. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 2: Map2 with a row vector
+	row, _ := mat.New([][]int{{2, 4}})
+	expected, _ = mat.New([][]int{
+		{2, 5},
+		{3, 4},
+	})
+	result, err = mat.Map2(m, row, func(a, b int) int { return max(a, b) })
+	if err != nil {
+		t.Errorf("Error in Map2: %v", err)
+	}
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in Map2. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 3: Integer division by zero
+	zero, _ := mat.Zeros[int](1, 1)
+	if _, err := mat.Div(m, zero); err == nil {
+		t.Errorf("Expected error for integer division by zero, but got nil")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
@@ -0,0 +1,181 @@
+package mat_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+func TestReadCSV(t *testing.T) {
+	start := time.Now()
+
+	// Test case 1: Header row and spaces around fields
+	input := "a,b,c\n1.5, 2,3\n4,5,6e-1\n"
+	expected, _ := mat.New([][]float64{
+		{1.5, 2, 3},
+		{4, 5, 0.6},
+	})
+
+	result, err := mat.ReadCSV[float64](strings.NewReader(input), mat.CSVOptions{SkipRows: 1})
+	if err != nil {
+		t.Errorf("Error reading CSV: %v", err)
+	}
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in ReadCSV. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 2: TSV with comments into an integer matrix
+	input = "# generated\n1\t2\n3\t4\n"
+	expectedInt, _ := mat.New([][]int32{
+		{1, 2},
+		{3, 4},
+	})
+
+	resultInt, err := mat.ReadCSV[int32](strings.NewReader(input), mat.CSVOptions{Comma: '\t', Comment: '#'})
+	if err != nil {
+		t.Errorf("Error reading TSV: %v", err)
+	}
+	if !mattest.EqualMatrix(resultInt, expectedInt) {
+		t.Errorf("Wrong result in ReadCSV (TSV). Want: %s\nGot: %s", expectedInt, resultInt)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestReadCSVMissingValues(t *testing.T) {
+	start := time.Now()
+
+	input := "1,,3\nNA,5,6\n"
+
+	// Test case 1: Missing values are an error by default
+	_, err := mat.ReadCSV[float64](strings.NewReader(input), mat.CSVOptions{})
+	var parseErr *mat.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Expected a ParseError for a missing value, got %v", err)
+	}
+	if parseErr.Line != 1 || parseErr.Column != 2 {
+		t.Errorf("Wrong position for missing value. Want: line 1, column 2, Got: line %d, column %d", parseErr.Line, parseErr.Column)
+	}
+
+	// Test case 2: Fill value, with NA counting as missing
+	result, err := mat.ReadCSV[float64](strings.NewReader(input), mat.CSVOptions{
+		Missing:       mat.MissingFill,
+		Fill:          -1,
+		MissingValues: []string{"NA"},
+	})
+	if err != nil {
+		t.Errorf("Error reading CSV with fill value: %v", err)
+	}
+	expected, _ := mat.New([][]float64{
+		{1, -1, 3},
+		{-1, 5, 6},
+	})
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result with fill value. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 3: NaN policy
+	result, err = mat.ReadCSV[float64](strings.NewReader("1,\n"), mat.CSVOptions{Missing: mat.MissingNaN})
+	if err != nil {
+		t.Errorf("Error reading CSV with NaN policy: %v", err)
+	}
+	if !math.IsNaN(result.Data[1]) {
+		t.Errorf("Expected NaN for missing value, Got: %f", result.Data[1])
+	}
+
+	// Test case 4: Empty lines in a single column are missing values, even after a
+	// header, a comment or a quoted field spanning lines
+	single := "x\n1\n\n# note\n\"2\n\"\n\n\n3\n\n"
+	_, err = mat.ReadCSV[float64](strings.NewReader(single), mat.CSVOptions{SkipRows: 1, Comment: '#'})
+	if !errors.As(err, &parseErr) || parseErr.Line != 3 {
+		t.Errorf("Expected a ParseError on line 3 for an empty line, got %v", err)
+	}
+	result, err = mat.ReadCSV[float64](strings.NewReader(single), mat.CSVOptions{SkipRows: 1, Comment: '#', Missing: mat.MissingZero})
+	if err != nil {
+		t.Fatalf("Error reading single column with missing values: %v", err)
+	}
+	expected, _ = mat.New([][]float64{{1}, {0}, {2}, {0}, {0}, {3}})
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result for single column. Want: %v\nGot: %v", expected.Data, result.Data)
+	}
+
+	// Test case 5: NaN policy is rejected for integers
+	_, err = mat.ReadCSV[int](strings.NewReader("1,\n"), mat.CSVOptions{Missing: mat.MissingNaN})
+	if err == nil {
+		t.Errorf("Expected error for NaN policy on an integer matrix, but got nil")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	start := time.Now()
+
+	testCases := []struct {
+		name   string
+		input  string
+		line   int
+		column int
+	}{
+		{"fraction in integer matrix", "1,2\n3,4.5\n", 2, 2},
+		{"text value", "1,2\nx,4\n", 2, 1},
+		{"ragged rows", "1,2\n3\n", 2, 0},
+		{"overflow", "1,200\n", 1, 2},
+		{"stray quote", "1,2\n3,4\"5\n", 2, 4},
+	}
+
+	for _, tc := range testCases {
+		_, err := mat.ReadCSV[int8](strings.NewReader(tc.input), mat.CSVOptions{})
+		var parseErr *mat.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%s: expected a ParseError, got %v", tc.name, err)
+			continue
+		}
+		if parseErr.Line != tc.line || parseErr.Column != tc.column {
+			t.Errorf("%s: wrong position. Want: line %d, column %d, Got: line %d, column %d", tc.name, tc.line, tc.column, parseErr.Line, parseErr.Column)
+		}
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestWriteCSVRoundTrip(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.New([][]float64{
+		{0.1, 1.0 / 3, -2},
+		{1e300, 5e-324, 7},
+	})
+
+	var buf bytes.Buffer
+	if err := m.WriteCSV(&buf); err != nil {
+		t.Errorf("Error writing CSV: %v", err)
+	}
+
+	result, err := mat.ReadCSV[float64](&buf, mat.CSVOptions{})
+	if err != nil {
+		t.Errorf("Error reading back CSV: %v", err)
+	}
+	for i := range m.Data {
+		if result.Data[i] != m.Data[i] {
+			t.Errorf("CSV round trip changed element %d. Want: %g, Got: %g", i, m.Data[i], result.Data[i])
+		}
+	}
+
+	buf.Reset()
+	if err := m.WriteTSV(&buf); err != nil {
+		t.Errorf("Error writing TSV: %v", err)
+	}
+	if !strings.Contains(buf.String(), "0.1\t") {
+		t.Errorf("Expected tab separated output, Got: %q", buf.String())
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
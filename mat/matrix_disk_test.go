@@ -0,0 +1,229 @@
+package mat_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+func randomIntMatrix(m, n int, seed int64) *mat.Mat[int] {
+	rng := rand.New(rand.NewSource(seed))
+	data := make([]int, m*n)
+	for i := range data {
+		data[i] = rng.Intn(21) - 10
+	}
+	return &mat.Mat[int]{M: m, N: n, Data: data}
+}
+
+// toDisk copies m into a new disk-backed matrix in dir.
+func toDisk(t *testing.T, dir, name string, m *mat.Mat[int]) *mat.DiskMat[int] {
+	t.Helper()
+	d, err := mat.CreateDisk[int](filepath.Join(dir, name), m.M, m.N)
+	if err != nil {
+		t.Fatalf("Error creating disk matrix: %v", err)
+	}
+	t.Cleanup(func() { d.Close() })
+	if err := d.SetBlock(0, 0, m); err != nil {
+		t.Fatalf("Error writing disk matrix: %v", err)
+	}
+	return d
+}
+
+func TestDiskMatBlocks(t *testing.T) {
+	start := time.Now()
+
+	dir := t.TempDir()
+	m := randomIntMatrix(7, 9, 1)
+	d := toDisk(t, dir, "m.gnmt", m)
+
+	// Test case 1: Whole matrix and a partial block read back the same
+	whole, err := d.Block(0, 7, 0, 9)
+	if err != nil {
+		t.Fatalf("Error reading block: %v", err)
+	}
+	if !mattest.EqualMatrix(whole, m) {
+		t.Errorf("Wrong result reading whole matrix. Want: %s\nGot: %s", m, whole)
+	}
+	part, _ := d.Block(2, 5, 3, 8)
+	if expected := mat.Sub(m, 2, 5, 3, 8); !mattest.EqualMatrix(part, expected) {
+		t.Errorf("Wrong result reading block. Want: %s\nGot: %s", expected, part)
+	}
+	if d.At(3, 4) != m.At(3, 4) {
+		t.Errorf("Wrong element. Want: %d, Got: %d", m.At(3, 4), d.At(3, 4))
+	}
+
+	// Test case 2: Writing a partial block
+	patch, _ := mat.Ones[int](2, 3)
+	if err := d.SetBlock(5, 6, patch); err != nil {
+		t.Fatalf("Error writing block: %v", err)
+	}
+	mat.SetSub(m, 5, 6, patch)
+	whole, _ = d.Block(0, 7, 0, 9)
+	if !mattest.EqualMatrix(whole, m) {
+		t.Errorf("Wrong result after writing block. Want: %s\nGot: %s", m, whole)
+	}
+
+	// Test case 3: Synced writes are in the file, and bad bounds are errors
+	if err := d.Sync(); err != nil {
+		t.Fatalf("Error syncing disk matrix: %v", err)
+	}
+	b, _ := os.ReadFile(filepath.Join(dir, "m.gnmt"))
+	if want, _ := m.MarshalBinary(); !bytes.Equal(b, want) {
+		t.Errorf("Synced file doesn't hold the written block")
+	}
+	var shapeErr *mat.ShapeError
+	if _, err := d.Block(0, 8, 0, 9); !errors.As(err, &shapeErr) {
+		t.Errorf("Expected a shape error reading a block out of range, got %v", err)
+	}
+	if err := d.SetBlock(6, 7, patch); !errors.As(err, &shapeErr) {
+		t.Errorf("Expected a shape error writing a block that doesn't fit, got %v", err)
+	}
+
+	// Test case 4: The file can be reopened and read like a marshaled matrix
+	if err := d.Close(); err != nil {
+		t.Fatalf("Error closing disk matrix: %v", err)
+	}
+	b, _ = os.ReadFile(filepath.Join(dir, "m.gnmt"))
+	var decoded mat.Mat[int]
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatalf("Error unmarshaling disk matrix file: %v", err)
+	}
+	if !mattest.EqualMatrix(&decoded, m) {
+		t.Errorf("Wrong result unmarshaling disk matrix file. Want: %s\nGot: %s", m, &decoded)
+	}
+
+	ro, err := mat.OpenDisk[int](filepath.Join(dir, "m.gnmt"), os.O_RDONLY)
+	if err != nil {
+		t.Fatalf("Error opening disk matrix: %v", err)
+	}
+	defer ro.Close()
+	if ro.M != 7 || ro.N != 9 || ro.At(6, 7) != 1 {
+		t.Errorf("Wrong matrix after reopening: %dx%d with element %d", ro.M, ro.N, ro.At(6, 7))
+	}
+	if err := ro.SetBlock(0, 0, patch); err == nil {
+		t.Errorf("Expected error writing to a read-only disk matrix, but got nil")
+	}
+
+	// Test case 5: The element type must match the file
+	if _, err := mat.OpenDisk[float64](filepath.Join(dir, "m.gnmt"), os.O_RDONLY); err == nil {
+		t.Errorf("Expected error opening int matrix as float64, but got nil")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestDotDisk(t *testing.T) {
+	start := time.Now()
+
+	dir := t.TempDir()
+
+	// Dimensions that aren't multiples of the tile size
+	m1 := randomIntMatrix(530, 600, 2)
+	m2 := randomIntMatrix(600, 520, 3)
+	expected, _ := mat.Dot(m1, m2)
+
+	a := toDisk(t, dir, "a.gnmt", m1)
+	b := toDisk(t, dir, "b.gnmt", m2)
+	dst, _ := mat.CreateDisk[int](filepath.Join(dir, "c.gnmt"), 530, 520)
+	defer dst.Close()
+
+	if err := mat.DotDisk(dst, a, b); err != nil {
+		t.Fatalf("Error multiplying disk matrices: %v", err)
+	}
+	result, _ := dst.Block(0, 530, 0, 520)
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result multiplying disk matrices")
+	}
+
+	if err := mat.DotDisk(dst, b, a); err == nil {
+		t.Errorf("Expected error multiplying incompatible matrices, but got nil")
+	}
+
+	// Test case 2: The destination can't share a file with an operand, even when the
+	// file is opened twice
+	sq := toDisk(t, dir, "sq.gnmt", randomIntMatrix(3, 3, 5))
+	before, _ := sq.Block(0, 3, 0, 3)
+	if err := mat.DotDisk(sq, sq, sq); err == nil {
+		t.Errorf("Expected error multiplying into an operand, but got nil")
+	}
+	reopened, err := mat.OpenDisk[int](filepath.Join(dir, "sq.gnmt"), os.O_RDWR)
+	if err != nil {
+		t.Fatalf("Error opening disk matrix: %v", err)
+	}
+	defer reopened.Close()
+	other := toDisk(t, dir, "other.gnmt", randomIntMatrix(3, 3, 6))
+	if err := mat.DotDisk(reopened, other, sq); err == nil {
+		t.Errorf("Expected error multiplying into a reopened operand, but got nil")
+	}
+	if err := mat.TransposeDisk(reopened, sq); err == nil {
+		t.Errorf("Expected error transposing into the source, but got nil")
+	}
+	if after, _ := sq.Block(0, 3, 0, 3); !mattest.EqualMatrix(after, before) {
+		t.Errorf("Rejected products modified the operand. Want: %s\nGot: %s", before, after)
+	}
+
+	// Test case 3: A disk matrix is a Matrix and can be read by the in-memory functions
+	var _ mat.Matrix[int] = sq
+	if rows, cols := sq.Dims(); rows != 3 || cols != 3 {
+		t.Errorf("Wrong dimensions. Want: 3x3, Got: %dx%d", rows, cols)
+	}
+	if copied := mat.DenseOf[int](sq); !mattest.EqualMatrix(copied, before) {
+		t.Errorf("Wrong result copying disk matrix. Want: %s\nGot: %s", before, copied)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestDiskTransposeAndReduce(t *testing.T) {
+	start := time.Now()
+
+	dir := t.TempDir()
+
+	// Large enough to be streamed in more than one band
+	m := randomIntMatrix(1100, 1000, 4)
+	d := toDisk(t, dir, "m.gnmt", m)
+
+	// Test case 1: Transpose
+	dst, _ := mat.CreateDisk[int](filepath.Join(dir, "t.gnmt"), 1000, 1100)
+	defer dst.Close()
+	if err := mat.TransposeDisk(dst, d); err != nil {
+		t.Fatalf("Error transposing disk matrix: %v", err)
+	}
+	result, _ := dst.Block(0, 1000, 0, 1100)
+	if !mattest.EqualMatrix(result, mat.Transpose(m)) {
+		t.Errorf("Wrong result transposing disk matrix")
+	}
+
+	// Test case 2: Reductions along every axis
+	for _, axis := range []mat.Axis{mat.AxisAll, mat.AxisRows, mat.AxisCols} {
+		sum, err := mat.SumAxisDisk(d, axis)
+		if err != nil {
+			t.Fatalf("Error summing disk matrix: %v", err)
+		}
+		if expected := mat.SumAxis(m, axis); !mattest.EqualMatrix(sum, expected) {
+			t.Errorf("Wrong sum along %v", axis)
+		}
+
+		mean, _ := mat.MeanAxisDisk(d, axis)
+		mattest.AssertEqual(t, mean, mat.MeanAxis(m, axis), mattest.Tolerance{Abs: 1e-12})
+
+		minimum, _ := mat.MinAxisDisk(d, axis)
+		if expected := mat.MinAxis(m, axis); !mattest.EqualMatrix(minimum, expected) {
+			t.Errorf("Wrong minimum along %v", axis)
+		}
+		maximum, _ := mat.MaxAxisDisk(d, axis)
+		if expected := mat.MaxAxis(m, axis); !mattest.EqualMatrix(maximum, expected) {
+			t.Errorf("Wrong maximum along %v", axis)
+		}
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
@@ -0,0 +1,76 @@
+package mat_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+)
+
+func TestMatrixExport(t *testing.T) {
+	start := time.Now()
+
+	m, err := mat.New([][]float64{
+		{1, 0.125},
+		{math.Inf(-1), math.NaN()},
+	})
+	if err != nil {
+		t.Errorf("Error creating matrix: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{
+			name:     "LaTeX",
+			result:   mat.FormatLaTeX(m, 2),
+			expected: "\\begin{bmatrix}\n1.00 & 0.12 \\\\\n-\\infty & \\mathrm{NaN}\n\\end{bmatrix}",
+		},
+		{
+			name:     "Markdown",
+			result:   mat.FormatMarkdown(m, -1),
+			expected: "| 1 | 2 |\n| ---: | ---: |\n| 1 | 0.125 |\n| -Inf | NaN |\n",
+		},
+		{
+			name:     "MATLAB",
+			result:   mat.FormatMATLAB(m, 1),
+			expected: "[1.0 0.1; -Inf NaN]",
+		},
+		{
+			name:     "NumPy",
+			result:   mat.FormatNumPy(m, -1),
+			expected: "np.array([[1.0, 0.125], [-np.inf, np.nan]])",
+		},
+	}
+
+	for _, tc := range testCases {
+		if tc.result != tc.expected {
+			t.Errorf("Wrong %s output. Want:\n%s\nGot:\n%s", tc.name, tc.expected, tc.result)
+		}
+	}
+
+	ints, _ := mat.New([][]int{{1, -2}, {3, 4}})
+
+	// NumPy floats keep a decimal point at any precision, so the dtype stays float
+	floats, _ := mat.New([][]float64{{2, -3e21}})
+	if result := mat.FormatNumPy(floats, 0); result != "np.array([[2.0, -3000000000000000000000.0]])" {
+		t.Errorf("Wrong NumPy output for integral floats. Got: %s", result)
+	}
+	if result := mat.FormatNumPy(floats, -1); result != "np.array([[2.0, -3e+21]])" {
+		t.Errorf("Wrong NumPy output for integral floats. Got: %s", result)
+	}
+	if result := mat.FormatNumPy(ints, 2); result != "np.array([[1, -2], [3, 4]])" {
+		t.Errorf("Wrong NumPy output for integers. Got: %s", result)
+	}
+
+	// Integers ignore the precision
+	if result := mat.FormatMATLAB(ints, 3); result != "[1 -2; 3 4]" {
+		t.Errorf("Wrong MATLAB output for integers. Got: %s", result)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
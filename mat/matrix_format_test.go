@@ -0,0 +1,79 @@
+package mat_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+)
+
+func TestMatrixFormat(t *testing.T) {
+	start := time.Now()
+
+	m, err := mat.New([][]float64{
+		{1, -2.5, 3},
+		{40, 5, 6},
+	})
+	if err != nil {
+		t.Errorf("Error creating matrix: %v", err)
+	}
+
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{"%v", m.String()},
+		{"%s", m.String()},
+		{"%+v", "2 x 3\n 1.00 -2.50 3.00\n40.00  5.00 6.00\n"},
+		{"%.3e", "2 x 3\n1.000e+00 -2.500e+00 3.000e+00\n4.000e+01  5.000e+00 6.000e+00\n"},
+		{"%8.3f", "2 x 3\n   1.000   -2.500    3.000\n  40.000    5.000    6.000\n"},
+		{"%#v", "[][]float64{{1, -2.5, 3}, {40, 5, 6}}"},
+	}
+
+	for _, tc := range testCases {
+		result := fmt.Sprintf(tc.format, m)
+		if result != tc.expected {
+			t.Errorf("Wrong output for %q. Want:\n%s\nGot:\n%s", tc.format, tc.expected, result)
+		}
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestMatrixFormatFull(t *testing.T) {
+	start := time.Now()
+
+	data := make([][]int, 5)
+	for i := range data {
+		data[i] = []int{i, 10 * i, 100 * i, 1000 * i, 2}
+	}
+	m, _ := mat.New(data)
+
+	// Test case 1: %v summarizes
+	expected := "5 x 5\n0 0 ... 2\n1 10 ... 2\n...\n4 40 ... 2\n"
+	if result := fmt.Sprintf("%v", m); result != expected {
+		t.Errorf("Wrong summary. Want:\n%s\nGot:\n%s", expected, result)
+	}
+
+	// Test case 2: %+v prints everything aligned
+	expected = "5 x 5\n0  0   0    0 2\n1 10 100 1000 2\n2 20 200 2000 2\n3 30 300 3000 2\n4 40 400 4000 2\n"
+	if result := fmt.Sprintf("%+v", m); result != expected {
+		t.Errorf("Wrong full output. Want:\n%s\nGot:\n%s", expected, result)
+	}
+
+	// Test case 3: Custom thresholds and alignment
+	opts := mat.FormatOptions{MaxRows: 2, MaxCols: 4, Align: mat.AlignLeft}
+	expected = "5 x 5\n0 0  0   ... 2\n...\n4 40 400 ... 2\n"
+	if result := fmt.Sprintf("%v", mat.Formatted(m, opts)); result != expected {
+		t.Errorf("Wrong output with options. Want:\n%s\nGot:\n%s", expected, result)
+	}
+
+	// Test case 4: Go syntax for integers
+	small, _ := mat.New([][]int8{{1, -2}})
+	if result := fmt.Sprintf("%#v", small); result != "[][]int8{{1, -2}}" {
+		t.Errorf("Wrong Go syntax output. Got: %s", result)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
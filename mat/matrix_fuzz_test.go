@@ -0,0 +1,189 @@
+package mat_test
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/lattots/gonum/mat"
+)
+
+// checkDecoded fails the test if a decoder returned a matrix with inconsistent dimensions.
+func checkDecoded[T int | float64](t *testing.T, m *mat.Mat[T]) {
+	t.Helper()
+	if m.M <= 0 || m.N <= 0 || m.M > math.MaxInt/m.N || len(m.Data) != m.M*m.N {
+		t.Fatalf("decoded matrix has dimensions %dx%d and %d elements", m.M, m.N, len(m.Data))
+	}
+}
+
+func FuzzNew(f *testing.F) {
+	f.Add([]byte{2, 2}, int64(7))
+	f.Add([]byte{3, 1, 3}, int64(-1))
+	f.Add([]byte{}, int64(0))
+
+	f.Fuzz(func(t *testing.T, rowLens []byte, seed int64) {
+		// Each byte gives the length of one row, with values taken from seed
+		data := make([][]int, len(rowLens)%64)
+		ragged := false
+		for i := range data {
+			data[i] = make([]int, rowLens[i]%8)
+			for j := range data[i] {
+				data[i][j] = int(seed) + i*8 + j
+			}
+			ragged = ragged || len(data[i]) != len(data[0])
+		}
+
+		m, err := mat.New(data)
+		if len(data) == 0 || len(data[0]) == 0 || ragged {
+			if err == nil {
+				t.Fatalf("expected error for rows of lengths %v", rowLens)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		checkDecoded(t, m)
+		for i := range data {
+			for j := range data[i] {
+				if m.At(i+1, j+1) != data[i][j] {
+					t.Fatalf("At(%d, %d) = %d, want %d", i+1, j+1, m.At(i+1, j+1), data[i][j])
+				}
+			}
+		}
+	})
+}
+
+func FuzzSliceRows(f *testing.F) {
+	f.Add(uint8(4), uint8(3), 1, 3)
+	f.Add(uint8(1), uint8(1), -5, 10)
+	f.Add(uint8(5), uint8(2), 3, 3)
+
+	f.Fuzz(func(t *testing.T, rows, cols uint8, start, end int) {
+		m, _ := mat.Zeros[int](int(rows)%32+1, int(cols)%32+1)
+		for i := range m.Data {
+			m.Data[i] = i
+		}
+
+		// Out of range bounds are clamped, an empty range panics
+		lo, hi := max(start, 0), min(end, m.M)
+		if lo >= hi {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected panic slicing rows [%d, %d) of %d", start, end, m.M)
+				}
+			}()
+		}
+
+		s := mat.SliceRows(m, start, end)
+		checkDecoded(t, s)
+		if s.M != hi-lo || s.N != m.N || s.Data[0] != lo*m.N {
+			t.Fatalf("SliceRows(%d, %d) of %dx%d returned %dx%d starting at %d", start, end, m.M, m.N, s.M, s.N, s.Data[0])
+		}
+	})
+}
+
+func FuzzUnmarshalBinary(f *testing.F) {
+	m, _ := mat.New([][]float64{{1, 2}, {3, 4}})
+	b, _ := m.MarshalBinary()
+	f.Add(b)
+	f.Add(b[:30])
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var m mat.Mat[float64]
+		if err := m.UnmarshalBinary(b); err != nil {
+			return
+		}
+		checkDecoded(t, &m)
+
+		// Re-encoding is stable
+		b1, err := m.MarshalBinary()
+		if err != nil {
+			t.Fatalf("error re-encoding decoded matrix: %v", err)
+		}
+		var m2 mat.Mat[float64]
+		if err := m2.UnmarshalBinary(b1); err != nil {
+			t.Fatalf("error decoding re-encoded matrix: %v", err)
+		}
+		if b2, _ := m2.MarshalBinary(); !bytes.Equal(b1, b2) {
+			t.Fatalf("binary encoding is not stable")
+		}
+	})
+}
+
+func FuzzUnmarshalJSON(f *testing.F) {
+	f.Add([]byte(`{"M":2,"N":1,"Data":[1,2]}`))
+	f.Add([]byte(`{"M":1,"N":1,"Data":[]}`))
+	f.Add([]byte(`{"M":4294967296,"N":4294967296,"Data":[]}`))
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var m mat.Mat[int]
+		if err := m.UnmarshalJSON(b); err != nil {
+			return
+		}
+		checkDecoded(t, &m)
+	})
+}
+
+func FuzzReadCSV(f *testing.F) {
+	f.Add("1,2\n3,4\n", uint8(0))
+	f.Add("a,b\n1,NaN\n", uint8(1))
+	f.Add("1,2\n3\n", uint8(0))
+
+	f.Fuzz(func(t *testing.T, s string, skip uint8) {
+		m, err := mat.ReadCSV[float64](strings.NewReader(s), mat.CSVOptions{SkipRows: int(skip)})
+		if err != nil {
+			return
+		}
+		checkDecoded(t, m)
+	})
+}
+
+func FuzzReadMatrixMarket(f *testing.F) {
+	f.Add("%%MatrixMarket matrix coordinate real general\n2 2 1\n1 2 3.5\n")
+	f.Add("%%MatrixMarket matrix array integer symmetric\n2 2\n1\n2\n3\n")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		m, err := mat.ReadMatrixMarket[float64](strings.NewReader(s))
+		if err != nil {
+			return
+		}
+		checkDecoded(t, m)
+	})
+}
+
+func FuzzReadNPY(f *testing.F) {
+	m, _ := mat.New([][]float64{{1, 2, 3}})
+	var buf bytes.Buffer
+	mat.WriteNPY(&buf, m)
+	f.Add(buf.Bytes())
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		m, err := mat.ReadNPY[float64](bytes.NewReader(b))
+		if err != nil {
+			return
+		}
+		checkDecoded(t, m)
+	})
+}
+
+func FuzzReadMAT(f *testing.F) {
+	m, _ := mat.New([][]float64{{1, 2}, {3, 4}})
+	for _, compress := range []bool{false, true} {
+		var buf bytes.Buffer
+		mat.WriteMAT(&buf, map[string]*mat.Mat[float64]{"m": m}, compress)
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		vars, err := mat.ReadMAT[float64](bytes.NewReader(b))
+		if err != nil {
+			return
+		}
+		for _, m := range vars {
+			checkDecoded(t, m)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package mat_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+// hilbert is a matrix defined outside the package that computes its elements on demand.
+type hilbert int
+
+func (h hilbert) Dims() (rows, cols int) {
+	return int(h), int(h)
+}
+
+func (h hilbert) At(i, j int) float64 {
+	return 1 / float64(i+j-1)
+}
+
+func TestMatrixInterface(t *testing.T) {
+	start := time.Now()
+
+	h := hilbert(3)
+	hDense, _ := mat.New([][]float64{{1, 1.0 / 2, 1.0 / 3}, {1.0 / 2, 1.0 / 3, 1.0 / 4}, {1.0 / 3, 1.0 / 4, 1.0 / 5}})
+
+	// Test case 1: Matrices defined outside the package are copied through At
+	if !mattest.EqualMatrix(mat.DenseOf[float64](h), hDense) {
+		t.Errorf("Wrong dense copy. Want: %s\nGot: %s", hDense, mat.DenseOf[float64](h))
+	}
+	got, err := mat.Dot[float64](h, hDense)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want, _ := mat.Dot(hDense, hDense)
+	if !mattest.EqualMatrix(got, want) {
+		t.Errorf("Wrong product. Want: %s\nGot: %s", want, got)
+	}
+	if !mattest.EqualMatrix(mat.Transpose[float64](h), hDense) {
+		t.Errorf("Expected the transpose of a symmetric matrix to be itself")
+	}
+	if mat.Max[float64](h) != 1 || mat.Min[float64](h) != 0.2 {
+		t.Errorf("Wrong extrema: %g and %g", mat.Max[float64](h), mat.Min[float64](h))
+	}
+
+	// Test case 2: Dense matrices are used as is
+	if mat.DenseOf[float64](hDense) != hDense {
+		t.Errorf("Expected DenseOf to return a Mat without copying")
+	}
+
+	// Test case 3: Structured and dense matrices mix
+	tri, _ := mat.NewTriDense[float64](3, false)
+	for i := 1; i <= 3; i++ {
+		for j := 1; j <= i; j++ {
+			tri.SetTri(i, j, h.At(i, j))
+		}
+	}
+	tri.SetTri(1, 1, 0)
+	sum := mat.Sum(tri, mat.Transpose(tri))
+	wantSum, _ := mat.New([][]float64{{0, 0.5, 1.0 / 3}, {0.5, 2.0 / 3, 0.25}, {1.0 / 3, 0.25, 0.4}})
+	mattest.AssertEqual(t, sum, wantSum, mattest.Tolerance{Abs: 1e-15})
+
+	diag, _ := mat.NewDiagDense([]float64{1, 2, 3})
+	got, _ = mat.Dot(diag, hDense)
+	want, _ = mat.Dot(diag.Dense(), hDense)
+	if !mattest.EqualMatrix(got, want) {
+		t.Errorf("Wrong diagonal product. Want: %s\nGot: %s", want, got)
+	}
+	square, _ := mat.Zeros[float64](2, 2)
+	if _, err := mat.Dot(diag, square); err == nil {
+		t.Errorf("Expected an error for mismatched shapes")
+	}
+
+	// Test case 4: Formatting works for any Matrix
+	if s, want := mat.FormatMATLAB(diag, 0), "[1 0 0; 0 2 0; 0 0 3]"; s != want {
+		t.Errorf("Wrong formatting. Want: %s, Got: %s", want, s)
+	}
+	if s := fmt.Sprintf("%v", mat.Formatted(tri, mat.FormatOptions{})); s != fmt.Sprintf("%v", mat.Formatted(tri.Dense(), mat.FormatOptions{})) {
+		t.Errorf("Wrong formatting of a triangular matrix: %s", s)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
@@ -0,0 +1,174 @@
+package mat_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.New([][]float64{
+		{1.0 / 3, math.Inf(-1)},
+		{-0.0, 1e-310},
+	})
+
+	// Test case 1: Round trip is bit exact
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Error marshaling matrix: %v", err)
+	}
+	if len(b) != 24+4*8 {
+		t.Errorf("Wrong encoded size. Want: %d, Got: %d", 24+4*8, len(b))
+	}
+
+	var result mat.Mat[float64]
+	if err := result.UnmarshalBinary(b); err != nil {
+		t.Fatalf("Error unmarshaling matrix: %v", err)
+	}
+	if result.M != m.M || result.N != m.N {
+		t.Errorf("Wrong dimensions after round trip. Want: %dx%d, Got: %dx%d", m.M, m.N, result.M, result.N)
+	}
+	for i := range m.Data {
+		if math.Float64bits(result.Data[i]) != math.Float64bits(m.Data[i]) {
+			t.Errorf("Binary round trip changed element %d. Want: %g, Got: %g", i, m.Data[i], result.Data[i])
+		}
+	}
+
+	// Test case 2: Truncated data
+	if err := result.UnmarshalBinary(b[:len(b)-1]); err == nil {
+		t.Errorf("Expected error unmarshaling truncated data, but got nil")
+	}
+
+	// Test case 3: Element type mismatch
+	var ints mat.Mat[int64]
+	if err := ints.UnmarshalBinary(b); err == nil {
+		t.Errorf("Expected error unmarshaling float64 data into an int64 matrix, but got nil")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestUnmarshalBinaryBigEndian(t *testing.T) {
+	start := time.Now()
+
+	// A 1x2 int32 matrix encoded with the big-endian flag set
+	b := []byte("GNMT")
+	b = append(b, 1, 3, 1, 0)
+	b = binary.BigEndian.AppendUint64(b, 1)
+	b = binary.BigEndian.AppendUint64(b, 2)
+	b = binary.BigEndian.AppendUint32(b, uint32(7))
+	b = binary.BigEndian.AppendUint32(b, uint32(0xFFFFFFFF))
+
+	var result mat.Mat[int32]
+	if err := result.UnmarshalBinary(b); err != nil {
+		t.Fatalf("Error unmarshaling big-endian matrix: %v", err)
+	}
+
+	expected, _ := mat.New([][]int32{{7, -1}})
+	if !mattest.EqualMatrix(&result, expected) {
+		t.Errorf("Wrong result unmarshaling big-endian matrix. Want: %s\nGot: %s", expected, &result)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestGobEncoding(t *testing.T) {
+	start := time.Now()
+
+	type payload struct {
+		Name   string
+		Matrix *mat.Mat[int]
+	}
+
+	m, _ := mat.New([][]int{
+		{1, 2, 3},
+		{4, 5, math.MinInt32},
+	})
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(payload{Name: "weights", Matrix: m}); err != nil {
+		t.Fatalf("Error gob encoding matrix: %v", err)
+	}
+
+	var result payload
+	if err := gob.NewDecoder(&buf).Decode(&result); err != nil {
+		t.Fatalf("Error gob decoding matrix: %v", err)
+	}
+	if !mattest.EqualMatrix(result.Matrix, m) {
+		t.Errorf("Wrong result in gob round trip. Want: %s\nGot: %s", m, result.Matrix)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestJSONEncoding(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.New([][]float32{
+		{1.5, 2},
+		{3, 4},
+	})
+
+	// Test case 1: Round trip
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Error encoding matrix as JSON: %v", err)
+	}
+	if string(b) != `{"M":2,"N":2,"Data":[1.5,2,3,4]}` {
+		t.Errorf("Unexpected JSON encoding: %s", b)
+	}
+
+	var result mat.Mat[float32]
+	if err := json.Unmarshal(b, &result); err != nil {
+		t.Fatalf("Error decoding matrix from JSON: %v", err)
+	}
+	if !mattest.EqualMatrix(&result, m) {
+		t.Errorf("Wrong result in JSON round trip. Want: %s\nGot: %s", m, &result)
+	}
+
+	// Test case 2: Data length must match the dimensions
+	if err := json.Unmarshal([]byte(`{"M":2,"N":2,"Data":[1,2,3]}`), &result); err == nil {
+		t.Errorf("Expected error decoding JSON with too few elements, but got nil")
+	}
+	if err := json.Unmarshal([]byte(`{"M":4294967296,"N":4294967296,"Data":[]}`), &result); err == nil {
+		t.Errorf("Expected error decoding JSON with dimensions whose product overflows, but got nil")
+	}
+
+	// Test case 3: NaN and the infinities, which JSON numbers can't hold, round trip as strings
+	special, _ := mat.New([][]float64{{math.NaN(), math.Inf(1)}, {math.Inf(-1), -0.5}})
+	b, err = json.Marshal(special)
+	if err != nil {
+		t.Fatalf("Error encoding matrix with special values as JSON: %v", err)
+	}
+	if string(b) != `{"M":2,"N":2,"Data":["NaN","+Inf","-Inf",-0.5]}` {
+		t.Errorf("Unexpected JSON encoding of special values: %s", b)
+	}
+	var decoded mat.Mat[float64]
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Error decoding matrix with special values from JSON: %v", err)
+	}
+	if !mattest.EqualMatrixTol(&decoded, special, mattest.Tolerance{NaNEqual: true}) {
+		t.Errorf("Wrong result in JSON round trip of special values. Want: %s\nGot: %s", special, &decoded)
+	}
+
+	// Test case 4: Other strings and special values in integer matrices are rejected
+	if err := json.Unmarshal([]byte(`{"M":1,"N":1,"Data":["Infinity"]}`), &decoded); err == nil {
+		t.Errorf("Expected error decoding an unknown string element, but got nil")
+	}
+	var ints mat.Mat[int]
+	if err := json.Unmarshal([]byte(`{"M":1,"N":1,"Data":["NaN"]}`), &ints); err == nil {
+		t.Errorf("Expected error decoding NaN into an integer matrix, but got nil")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
@@ -0,0 +1,199 @@
+package mat_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+// matElement appends a padded MAT-file data element in the given byte order.
+func matElement(b []byte, order binary.AppendByteOrder, miType uint32, data []byte) []byte {
+	b = order.AppendUint32(b, miType)
+	b = order.AppendUint32(b, uint32(len(data)))
+	b = append(b, data...)
+	return append(b, make([]byte, (8-len(data)%8)%8)...)
+}
+
+// matSmallElement appends a data element of at most 4 bytes in the small element format.
+func matSmallElement(b []byte, order binary.AppendByteOrder, miType uint32, data []byte) []byte {
+	b = order.AppendUint32(b, uint32(len(data))<<16|miType)
+	return append(b, append(data, make([]byte, 4-len(data))...)...)
+}
+
+// matArray builds a miMATRIX element the way MATLAB writes it, with the smallest
+// data type that holds the values.
+func matArray(order binary.AppendByteOrder, class uint32, rows, cols int32, name string, miType uint32, data []byte) []byte {
+	flags := order.AppendUint32(nil, class)
+	flags = order.AppendUint32(flags, 0)
+	dims := order.AppendUint32(nil, uint32(rows))
+	dims = order.AppendUint32(dims, uint32(cols))
+
+	var payload []byte
+	payload = matElement(payload, order, 6, flags)
+	payload = matElement(payload, order, 5, dims)
+	if len(name) <= 4 {
+		payload = matSmallElement(payload, order, 1, []byte(name))
+	} else {
+		payload = matElement(payload, order, 1, []byte(name))
+	}
+	payload = matElement(payload, order, miType, data)
+
+	return matElement(nil, order, 14, payload)
+}
+
+func TestReadMATBigEndian(t *testing.T) {
+	start := time.Now()
+
+	order := binary.BigEndian
+	header := make([]byte, 128)
+	copy(header, "MATLAB 5.0 MAT-file")
+	binary.BigEndian.PutUint16(header[124:], 0x0100)
+	copy(header[126:], "MI")
+
+	file := append([]byte{}, header...)
+	// A 2x3 double array stored column by column as miUINT8
+	file = append(file, matArray(order, 6, 2, 3, "a", 2, []byte{1, 4, 2, 5, 3, 6})...)
+	// A char array, which is skipped
+	file = append(file, matArray(order, 4, 1, 2, "label", 4, []byte{0, 'h', 0, 'i'})...)
+
+	vars, err := mat.ReadMAT[float64](bytes.NewReader(file))
+	if err != nil {
+		t.Fatalf("Error reading MAT-file: %v", err)
+	}
+	if len(vars) != 1 {
+		t.Errorf("Wrong number of variables. Want: 1, Got: %d", len(vars))
+	}
+
+	expected, _ := mat.New([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	if !mattest.EqualMatrix(vars["a"], expected) {
+		t.Errorf("Wrong result reading MAT-file. Want: %s\nGot: %s", expected, vars["a"])
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestMATRoundTrip(t *testing.T) {
+	start := time.Now()
+
+	x, _ := mat.New([][]float64{
+		{0.1, 0.2, 0.3},
+		{1e-300, -4, 5},
+	})
+	y, _ := mat.New([][]float64{{1.0 / 3}})
+	vars := map[string]*mat.Mat[float64]{"x": x, "long_variable_name": y}
+
+	for _, compress := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := mat.WriteMAT(&buf, vars, compress); err != nil {
+			t.Errorf("Error writing MAT-file (compress=%v): %v", compress, err)
+			continue
+		}
+
+		result, err := mat.ReadMAT[float64](&buf)
+		if err != nil {
+			t.Errorf("Error reading MAT-file (compress=%v): %v", compress, err)
+			continue
+		}
+
+		for name, want := range vars {
+			got, ok := result[name]
+			if !ok {
+				t.Errorf("Variable %q missing from MAT-file (compress=%v)", name, compress)
+				continue
+			}
+			if got.M != want.M || got.N != want.N {
+				t.Errorf("Wrong dimensions for %q. Want: %dx%d, Got: %dx%d", name, want.M, want.N, got.M, got.N)
+				continue
+			}
+			for i := range want.Data {
+				if got.Data[i] != want.Data[i] {
+					t.Errorf("MAT-file round trip changed %s element %d. Want: %g, Got: %g", name, i, want.Data[i], got.Data[i])
+				}
+			}
+		}
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestMATConversion(t *testing.T) {
+	start := time.Now()
+
+	ints, _ := mat.New([][]int32{{1, -2}, {3, 4}})
+
+	var buf bytes.Buffer
+	if err := mat.WriteMAT(&buf, map[string]*mat.Mat[int32]{"n": ints}, true); err != nil {
+		t.Fatalf("Error writing MAT-file: %v", err)
+	}
+	encoded := buf.Bytes()
+
+	// Integer arrays can be read as floats
+	floats, err := mat.ReadMAT[float64](bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("Error reading int32 MAT-file as float64: %v", err)
+	}
+	expected, _ := mat.New([][]float64{{1, -2}, {3, 4}})
+	if !mattest.EqualMatrix(floats["n"], expected) {
+		t.Errorf("Wrong result converting MAT-file. Want: %s\nGot: %s", expected, floats["n"])
+	}
+
+	// Fractional values can't be read as integers
+	frac, _ := mat.New([][]float64{{0.5}})
+	buf.Reset()
+	mat.WriteMAT(&buf, map[string]*mat.Mat[float64]{"f": frac}, false)
+	if _, err := mat.ReadMAT[int](&buf); err == nil {
+		t.Errorf("Expected error reading fractional values into an int matrix, but got nil")
+	}
+
+	// Invalid variable names are rejected
+	if err := mat.WriteMAT(&bytes.Buffer{}, map[string]*mat.Mat[int32]{"1bad": ints}, false); err == nil {
+		t.Errorf("Expected error writing an invalid variable name, but got nil")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestReadMATCompressedSize(t *testing.T) {
+	start := time.Now()
+
+	order := binary.LittleEndian
+	header := make([]byte, 128)
+	copy(header, "MATLAB 5.0 MAT-file")
+	order.PutUint16(header[124:], 0x0100)
+	copy(header[126:], "IM")
+
+	// compressed wraps an element tag declaring size bytes followed by n zero bytes
+	compressed := func(size uint32, n int) []byte {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		zw.Write(order.AppendUint32(order.AppendUint32(nil, 14), size))
+		zw.Write(make([]byte, n))
+		zw.Close()
+		return matElement(append([]byte{}, header...), order, 15, buf.Bytes())
+	}
+
+	// Test case 1: A stream that decompresses to far more than its declared size is rejected
+	bomb := compressed(16, 64<<20)
+	if len(bomb) > 1<<20 {
+		t.Fatalf("Test input is not small: %d bytes", len(bomb))
+	}
+	if _, err := mat.ReadMAT[float64](bytes.NewReader(bomb)); err == nil {
+		t.Errorf("Expected error for a compressed element longer than declared, but got nil")
+	}
+
+	// Test case 2: A stream shorter than its declared size is truncated
+	if _, err := mat.ReadMAT[float64](bytes.NewReader(compressed(1<<30, 16))); err == nil {
+		t.Errorf("Expected error for a truncated compressed element, but got nil")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
@@ -0,0 +1,190 @@
+package mat_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+func eye(n int) *mat.Mat[float64] {
+	m, _ := mat.Zeros[float64](n, n)
+	for i := range n {
+		m.Data[i*n+i] = 1
+	}
+	return m
+}
+
+func TestExpm(t *testing.T) {
+	start := time.Now()
+
+	tol := mattest.Tolerance{Abs: 1e-13, Rel: 1e-12}
+
+	// Test case 1: Closed forms for every Padé degree and for scaling
+	for _, theta := range []float64{0.01, 0.2, 0.9, 2, 5, 40} {
+		s, c := math.Sincos(theta)
+		gen, _ := mat.New([][]float64{{0, -theta}, {theta, 0}})
+		rot, _ := mat.New([][]float64{{c, -s}, {s, c}})
+		got, err := mat.Expm(gen)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !mattest.AssertEqual(t, got, rot, tol) {
+			t.Errorf("Wrong exponential of a rotation generator by %g", theta)
+		}
+
+		diag, _ := mat.New([][]float64{{theta, 0}, {0, -theta}})
+		want, _ := mat.New([][]float64{{math.Exp(theta), 0}, {0, math.Exp(-theta)}})
+		got, _ = mat.Expm(diag)
+		mattest.AssertEqual(t, got, want, tol)
+	}
+
+	// Test case 2: Nilpotent matrices give a finite series
+	n, _ := mat.New([][]float64{{0, 1, 2}, {0, 0, 3}, {0, 0, 0}})
+	want, _ := mat.New([][]float64{{1, 1, 3.5}, {0, 1, 3}, {0, 0, 1}})
+	got, _ := mat.Expm(n)
+	mattest.AssertEqual(t, got, want, tol)
+
+	// Test case 3: e^A e^-A = I for a random matrix with a large norm
+	rng := rand.New(rand.NewSource(1))
+	a := mat.Scale(mattest.Random[float64](rng, 6, 6), 3)
+	ea, _ := mat.Expm(a)
+	eNeg, _ := mat.Expm(mat.Scale(a, -1))
+	prod, _ := mat.Dot(ea, eNeg)
+	mattest.AssertEqual(t, prod, eye(6), mattest.Tolerance{Abs: 1e-9})
+
+	// Test case 4: Float32 and invalid input
+	a32, _ := mat.New([][]float32{{0, 1}, {0, 0}})
+	want32, _ := mat.New([][]float32{{1, 1}, {0, 1}})
+	got32, _ := mat.Expm(a32)
+	mattest.AssertEqual(t, got32, want32, mattest.Tolerance{Abs: 1e-6})
+
+	var shapeErr *mat.ShapeError
+	if _, err := mat.Expm(mattest.Random[float64](rng, 2, 3)); !errors.As(err, &shapeErr) {
+		t.Errorf("Expected a shape error, got %v", err)
+	}
+	inf, _ := mat.New([][]float64{{math.Inf(1)}})
+	if _, err := mat.Expm(inf); err == nil {
+		t.Errorf("Expected an error for a non-finite matrix")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestSqrtmLogm(t *testing.T) {
+	start := time.Now()
+
+	rng := rand.New(rand.NewSource(2))
+	tol := mattest.Tolerance{Abs: 1e-10, Rel: 1e-10}
+
+	// Test case 1: Known roots and logarithms of a diagonal matrix
+	d, _ := mat.New([][]float64{{4, 0}, {0, 9}})
+	wantRoot, _ := mat.New([][]float64{{2, 0}, {0, 3}})
+	root, err := mat.Sqrtm(d)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	mattest.AssertEqual(t, root, wantRoot, tol)
+	wantLog, _ := mat.New([][]float64{{math.Log(4), 0}, {0, math.Log(9)}})
+	log, err := mat.Logm(d)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	mattest.AssertEqual(t, log, wantLog, tol)
+
+	// Test case 2: The square root squares back for ill conditioned and non-symmetric matrices
+	inputs := []*mat.Mat[float64]{
+		mattest.RandomSPD[float64](rng, 5, 1e4),
+		mat.Sum(eye(6), mat.Scale(mattest.Random[float64](rng, 6, 6), 0.3)),
+	}
+	for _, a := range inputs {
+		root, err := mat.Sqrtm(a)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		sq, _ := mat.Dot(root, root)
+		mattest.AssertEqual(t, sq, a, tol)
+
+		// Test case 3: The logarithm inverts the exponential
+		log, err := mat.Logm(a)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		exp, _ := mat.Expm(log)
+		mattest.AssertEqual(t, exp, a, tol)
+	}
+
+	// A rotation by more than π/2 needs several square roots before the series applies
+	s, c := math.Sincos(2.5)
+	rot, _ := mat.New([][]float64{{c, -s}, {s, c}})
+	wantLog, _ = mat.New([][]float64{{0, -2.5}, {2.5, 0}})
+	log, _ = mat.Logm(rot)
+	mattest.AssertEqual(t, log, wantLog, tol)
+
+	// Test case 4: Singular matrices and negative eigenvalues have no real root or logarithm
+	singular, _ := mat.New([][]float64{{1, 2}, {2, 4}})
+	if _, err := mat.Sqrtm(singular); !errors.Is(err, mat.ErrSingular) {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+	if _, err := mat.Logm(singular); !errors.Is(err, mat.ErrSingular) {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+	negative, _ := mat.New([][]float64{{-1, 0}, {0, 4}})
+	if _, err := mat.Sqrtm(negative); err == nil {
+		t.Errorf("Expected an error for a negative eigenvalue")
+	}
+	if _, err := mat.Logm(negative); err == nil {
+		t.Errorf("Expected an error for a negative eigenvalue")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestPow(t *testing.T) {
+	start := time.Now()
+
+	// Test case 1: Fibonacci numbers from integer powers
+	fib, _ := mat.New([][]int{{1, 1}, {1, 0}})
+	want, _ := mat.New([][]int{{10946, 6765}, {6765, 4181}})
+	got, err := mat.Pow(fib, 20)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !mattest.EqualMatrix(got, want) {
+		t.Errorf("Wrong power. Want: %s\nGot: %s", want, got)
+	}
+
+	// Test case 2: Powers 0 and 1 return new matrices
+	identity, _ := mat.New([][]int{{1, 0}, {0, 1}})
+	got, _ = mat.Pow(fib, 0)
+	if !mattest.EqualMatrix(got, identity) {
+		t.Errorf("Expected the identity, got %s", got)
+	}
+	got, _ = mat.Pow(fib, 1)
+	got.Data[0] = 7
+	if fib.Data[0] != 1 {
+		t.Errorf("Expected Pow to return a copy for power 1")
+	}
+
+	// Test case 3: A Markov chain reaches its stationary distribution
+	p, _ := mat.New([][]float64{{0.9, 0.1}, {0.5, 0.5}})
+	stationary, _ := mat.New([][]float64{{5.0 / 6, 1.0 / 6}, {5.0 / 6, 1.0 / 6}})
+	pk, _ := mat.Pow(p, 1000)
+	mattest.AssertEqual(t, pk, stationary, mattest.Tolerance{Abs: 1e-12})
+
+	// Test case 4: Invalid input
+	if _, err := mat.Pow(fib, -1); err == nil {
+		t.Errorf("Expected an error for a negative power")
+	}
+	rect, _ := mat.Zeros[int](2, 3)
+	if _, err := mat.Pow(rect, 2); err == nil {
+		t.Errorf("Expected an error for a non-square matrix")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
@@ -0,0 +1,240 @@
+package mat_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+func TestReadMatrixMarket(t *testing.T) {
+	start := time.Now()
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected [][]float64
+	}{
+		{
+			name: "coordinate real general",
+			input: `%%MatrixMarket matrix coordinate real general
+% a comment
+2 3 3
+1 1 1.5
+2 3 -2
+1 2 4e1
+`,
+			expected: [][]float64{
+				{1.5, 40, 0},
+				{0, 0, -2},
+			},
+		},
+		{
+			name: "coordinate pattern symmetric",
+			input: `%%MatrixMarket matrix coordinate pattern symmetric
+3 3 2
+2 1
+3 3
+`,
+			expected: [][]float64{
+				{0, 1, 0},
+				{1, 0, 0},
+				{0, 0, 1},
+			},
+		},
+		{
+			name: "array integer skew-symmetric",
+			input: `%%MatrixMarket matrix array integer skew-symmetric
+3 3
+1
+2
+3
+`,
+			expected: [][]float64{
+				{0, -1, -2},
+				{1, 0, -3},
+				{2, 3, 0},
+			},
+		},
+		{
+			name: "array real general",
+			input: `%%MatrixMarket matrix array real general
+2 2
+1
+2
+3
+4
+`,
+			expected: [][]float64{
+				{1, 3},
+				{2, 4},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		expected, _ := mat.New(tc.expected)
+		result, err := mat.ReadMatrixMarket[float64](strings.NewReader(tc.input))
+		if err != nil {
+			t.Errorf("%s: error reading matrix: %v", tc.name, err)
+			continue
+		}
+		if !mattest.EqualMatrix(result, expected) {
+			t.Errorf("%s: wrong result. Want: %s\nGot: %s", tc.name, expected, result)
+		}
+
+		sparse, err := mat.ReadMatrixMarketCSR[float64](strings.NewReader(tc.input))
+		if err != nil {
+			t.Errorf("%s: error reading sparse matrix: %v", tc.name, err)
+			continue
+		}
+		if !mattest.EqualMatrix(sparse, expected) {
+			t.Errorf("%s: wrong sparse result. Want: %s\nGot: %s", tc.name, expected, sparse.Dense())
+		}
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestReadMatrixMarketErrors(t *testing.T) {
+	start := time.Now()
+
+	testCases := []struct {
+		name  string
+		input string
+		line  int
+	}{
+		{"missing header", "2 2 0\n", 1},
+		{"complex field", "%%MatrixMarket matrix coordinate complex general\n1 1 0\n", 1},
+		{"index out of range", "%%MatrixMarket matrix coordinate real general\n2 2 1\n3 1 1.0\n", 3},
+		{"too few entries", "%%MatrixMarket matrix coordinate real general\n2 2 2\n1 1 1.0\n", 3},
+		{"fraction in integer matrix", "%%MatrixMarket matrix coordinate real general\n1 1 1\n1 1 1.5\n", 3},
+	}
+
+	for _, tc := range testCases {
+		_, err := mat.ReadMatrixMarket[int](strings.NewReader(tc.input))
+		var parseErr *mat.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%s: expected a ParseError, got %v", tc.name, err)
+			continue
+		}
+		if parseErr.Line != tc.line {
+			t.Errorf("%s: wrong line in error. Want: %d, Got: %d (%v)", tc.name, tc.line, parseErr.Line, err)
+		}
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestReadMatrixMarketCSR(t *testing.T) {
+	start := time.Now()
+
+	// Test case 1: A matrix far too large to expand is read in memory proportional to its entries
+	input := `%%MatrixMarket matrix coordinate real symmetric
+1000000 1000000 4
+1000000 1 2.5
+3 3 1
+3 3 1
+500000 3 -1
+`
+	c, err := mat.ReadMatrixMarketCSR[float64](strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Error reading sparse matrix: %v", err)
+	}
+	if r, cols := c.Dims(); r != 1000000 || cols != 1000000 || c.NNZ() != 5 {
+		t.Errorf("Wrong sparse matrix: %s", c)
+	}
+	if c.At(1, 1000000) != 2.5 || c.At(1000000, 1) != 2.5 || c.At(3, 3) != 2 || c.At(3, 500000) != -1 || c.At(2, 2) != 0 {
+		t.Errorf("Wrong elements in sparse matrix")
+	}
+
+	// Test case 2: The dense reader refuses to expand it
+	_, err = mat.ReadMatrixMarket[float64](strings.NewReader(input))
+	var parseErr *mat.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 {
+		t.Errorf("Expected a ParseError on line 2 for a matrix too large to expand, got %v", err)
+	}
+
+	// Test case 3: Products agree with the dense matrix, and entries that sum to zero are dropped
+	input = `%%MatrixMarket matrix coordinate integer general
+3 4 6
+2 4 7
+1 2 3
+2 1 -1
+2 4 -7
+3 3 5
+1 1 2
+`
+	ci, _ := mat.ReadMatrixMarketCSR[int](strings.NewReader(input))
+	dense, _ := mat.ReadMatrixMarket[int](strings.NewReader(input))
+	if ci.NNZ() != 4 || !mattest.EqualMatrix(ci.Dense(), dense) {
+		t.Errorf("Wrong sparse matrix. Want: %s\nGot: %s", dense, ci.Dense())
+	}
+	v, _ := mat.NewVec([]int{1, 2, 3, 4})
+	got, _ := mat.MulVecCSR(ci, v)
+	want, _ := mat.MulVec(dense, v)
+	if !mattest.EqualMatrix(got.ColMat(), want.ColMat()) {
+		t.Errorf("Wrong product. Want: %v\nGot: %v", want, got)
+	}
+	if _, err := mat.MulVecCSR(ci, got); err == nil {
+		t.Errorf("Expected an error for a vector of the wrong length")
+	}
+	expectPanic(t, "At out of range", func() { ci.At(4, 1) })
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestWriteMatrixMarketRoundTrip(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.New([][]float64{
+		{4, 0.1, 0},
+		{0.1, 5, 1e-20},
+		{0, 1e-20, 6},
+	})
+
+	testCases := []mat.MatrixMarketOptions{
+		{Format: mat.MatrixMarketCoordinate, Symmetry: mat.MatrixMarketGeneral},
+		{Format: mat.MatrixMarketArray, Symmetry: mat.MatrixMarketGeneral, Comment: "dense\nexport"},
+		{Format: mat.MatrixMarketCoordinate, Symmetry: mat.MatrixMarketSymmetric},
+		{Format: mat.MatrixMarketArray, Symmetry: mat.MatrixMarketSymmetric},
+	}
+
+	for _, opts := range testCases {
+		var buf bytes.Buffer
+		if err := mat.WriteMatrixMarket(&buf, m, opts); err != nil {
+			t.Errorf("Error writing matrix with %+v: %v", opts, err)
+			continue
+		}
+
+		result, err := mat.ReadMatrixMarket[float64](&buf)
+		if err != nil {
+			t.Errorf("Error reading back matrix written with %+v: %v", opts, err)
+			continue
+		}
+
+		// Values are written with full precision, so the round trip is exact
+		for i := range m.Data {
+			if result.Data[i] != m.Data[i] {
+				t.Errorf("Round trip with %+v changed element %d. Want: %g, Got: %g", opts, i, m.Data[i], result.Data[i])
+			}
+		}
+	}
+
+	// Non-symmetric matrix can't be written as symmetric
+	nonSym, _ := mat.New([][]int{
+		{1, 2},
+		{3, 4},
+	})
+	err := mat.WriteMatrixMarket(&bytes.Buffer{}, nonSym, mat.MatrixMarketOptions{Symmetry: mat.MatrixMarketSymmetric})
+	if err == nil {
+		t.Errorf("Expected error writing a non-symmetric matrix as symmetric, but got nil")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
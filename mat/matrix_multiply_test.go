@@ -5,8 +5,8 @@ import (
 	"testing"
 	"time"
 
-	"github.com/lattots/gonum/internal/util"
 	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
 )
 
 func TestMatrixDot(t *testing.T) {
@@ -51,7 +51,7 @@ func TestMatrixDot(t *testing.T) {
 		t.Errorf("Error during matrix multiplication: %v", err)
 	}
 
-	if !util.EqualMatrix(result, expected) {
+	if !mattest.EqualMatrix(result, expected) {
 		t.Errorf("Wrong result in matrix dot product. Want: %s\nGot: %s", expected, result)
 	}
 
@@ -139,7 +139,7 @@ func TestLargeMatrixMultiply(t *testing.T) {
 
 	fmt.Printf("Strassen multiply took: %v\n", time.Since(start))
 
-	if !util.EqualMatrix(result, expected) {
+	if !mattest.EqualMatrix(result, expected) {
 		t.Errorf("Wrong result in matrix dot product. Want: %s\nGot: %s", expected, result)
 	}
 }
@@ -179,7 +179,7 @@ func TestMatrixMul(t *testing.T) {
 		t.Errorf("Error multiplying matrices element wise: %v", err)
 	}
 
-	if !util.EqualMatrix(result, expected) {
+	if !mattest.EqualMatrix(result, expected) {
 		t.Errorf("Wrong result in element wise matrix multiplication. Want: %s\nGot: %s", expected, result)
 	}
 
@@ -212,10 +212,10 @@ func TestMatrixSquare(t *testing.T) {
 
 	result1, result2 := mat.Square(m1, m2)
 
-	if !util.EqualMatrix(result1, expected1) {
+	if !mattest.EqualMatrix(result1, expected1) {
 		t.Errorf("Wrong result squaring matrices. Want: %s\nGot: %s", expected1, result1)
 	}
-	if !util.EqualMatrix(result2, expected2) {
+	if !mattest.EqualMatrix(result2, expected2) {
 		t.Errorf("Wrong result squaring matrices. Want: %s\nGot: %s", expected2, result2)
 	}
 }
@@ -247,16 +247,16 @@ func TestMatrixSplit(t *testing.T) {
 
 	m11, m12, m21, m22 := mat.Split(m)
 
-	if !util.EqualMatrix(m11, expected11) {
+	if !mattest.EqualMatrix(m11, expected11) {
 		t.Errorf("Wrong result splitting a matrix. Want: %s\nGot: %s", expected11, m11)
 	}
-	if !util.EqualMatrix(m12, expected12) {
+	if !mattest.EqualMatrix(m12, expected12) {
 		t.Errorf("Wrong result splitting a matrix. Want: %s\nGot: %s", expected12, m12)
 	}
-	if !util.EqualMatrix(m21, expected21) {
+	if !mattest.EqualMatrix(m21, expected21) {
 		t.Errorf("Wrong result splitting a matrix. Want: %s\nGot: %s", expected21, m21)
 	}
-	if !util.EqualMatrix(m22, expected22) {
+	if !mattest.EqualMatrix(m22, expected22) {
 		t.Errorf("Wrong result splitting a matrix. Want: %s\nGot: %s", expected22, m22)
 	}
 }
@@ -288,7 +288,7 @@ func TestMatrixCombine(t *testing.T) {
 
 	result := mat.Combine(m11, m12, m21, m22, m11.M)
 
-	if !util.EqualMatrix(result, expected) {
+	if !mattest.EqualMatrix(result, expected) {
 		t.Errorf("Wrong result combining matrices. Want: %s\nGot: %s", expected, result)
 	}
 }
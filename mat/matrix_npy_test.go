@@ -0,0 +1,156 @@
+package mat_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+// npyBytes builds a version 1.0 npy file the way numpy.save lays it out.
+func npyBytes(header string, data any, order binary.ByteOrder) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("\x93NUMPY\x01\x00")
+
+	padding := 64 - (10+len(header)+1)%64
+	if padding == 64 {
+		padding = 0
+	}
+	header += strings.Repeat(" ", padding) + "\n"
+
+	binary.Write(&buf, binary.LittleEndian, uint16(len(header)))
+	buf.WriteString(header)
+	binary.Write(&buf, order, data)
+	return buf.Bytes()
+}
+
+func TestReadNPY(t *testing.T) {
+	start := time.Now()
+
+	expected, _ := mat.New([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+
+	testCases := []struct {
+		name  string
+		input []byte
+	}{
+		{
+			name:  "C order",
+			input: npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }", []float64{1, 2, 3, 4, 5, 6}, binary.LittleEndian),
+		},
+		{
+			name:  "Fortran order",
+			input: npyBytes("{'descr': '<f8', 'fortran_order': True, 'shape': (2, 3), }", []float64{1, 4, 2, 5, 3, 6}, binary.LittleEndian),
+		},
+		{
+			name:  "big-endian",
+			input: npyBytes("{'descr': '>f8', 'fortran_order': False, 'shape': (2, 3), }", []float64{1, 2, 3, 4, 5, 6}, binary.BigEndian),
+		},
+	}
+
+	for _, tc := range testCases {
+		result, err := mat.ReadNPY[float64](bytes.NewReader(tc.input))
+		if err != nil {
+			t.Errorf("%s: error reading npy: %v", tc.name, err)
+			continue
+		}
+		if !mattest.EqualMatrix(result, expected) {
+			t.Errorf("%s: wrong result. Want: %s\nGot: %s", tc.name, expected, result)
+		}
+	}
+
+	// 1-D arrays become row vectors
+	vec := npyBytes("{'descr': '<i4', 'fortran_order': False, 'shape': (3,), }", []int32{7, 8, 9}, binary.LittleEndian)
+	result, err := mat.ReadNPY[int32](bytes.NewReader(vec))
+	if err != nil {
+		t.Errorf("Error reading 1-D npy: %v", err)
+	} else if result.M != 1 || result.N != 3 {
+		t.Errorf("Wrong dimensions for 1-D npy. Want: 1x3, Got: %dx%d", result.M, result.N)
+	}
+
+	// Mismatched dtype is rejected
+	if _, err := mat.ReadNPY[float32](bytes.NewReader(testCases[0].input)); err == nil {
+		t.Errorf("Expected error reading '<f8' data into a float32 matrix, but got nil")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestWriteNPYRoundTrip(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.New([][]int16{
+		{-1, 2},
+		{300, -32768},
+	})
+
+	var buf bytes.Buffer
+	if err := mat.WriteNPY(&buf, m); err != nil {
+		t.Errorf("Error writing npy: %v", err)
+	}
+
+	// Data must start on a 64 byte boundary as required by the format
+	headerLen := int(binary.LittleEndian.Uint16(buf.Bytes()[8:10]))
+	if (10+headerLen)%64 != 0 {
+		t.Errorf("npy data is not 64 byte aligned, header length %d", headerLen)
+	}
+	if !strings.Contains(buf.String(), "'descr': '<i2'") {
+		t.Errorf("Expected '<i2' dtype in header, Got: %q", buf.String()[10:10+headerLen])
+	}
+
+	result, err := mat.ReadNPY[int16](&buf)
+	if err != nil {
+		t.Errorf("Error reading back npy: %v", err)
+	}
+	if !mattest.EqualMatrix(result, m) {
+		t.Errorf("Wrong result in npy round trip. Want: %s\nGot: %s", m, result)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestNPZRoundTrip(t *testing.T) {
+	start := time.Now()
+
+	x, _ := mat.New([][]float64{{0.1, 0.2}, {0.3, 0.4}})
+	y, _ := mat.New([][]float64{{1.0 / 3}})
+	arrays := map[string]*mat.Mat[float64]{"x": x, "y": y}
+
+	for _, compress := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := mat.WriteNPZ(&buf, arrays, compress); err != nil {
+			t.Errorf("Error writing npz (compress=%v): %v", compress, err)
+			continue
+		}
+
+		result, err := mat.ReadNPZ[float64](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Errorf("Error reading npz (compress=%v): %v", compress, err)
+			continue
+		}
+		if len(result) != len(arrays) {
+			t.Errorf("Wrong number of arrays in npz. Want: %d, Got: %d", len(arrays), len(result))
+		}
+		for name, want := range arrays {
+			got, ok := result[name]
+			if !ok {
+				t.Errorf("Array %q missing from npz", name)
+				continue
+			}
+			for i := range want.Data {
+				if got.Data[i] != want.Data[i] {
+					t.Errorf("npz round trip changed %s element %d. Want: %g, Got: %g", name, i, want.Data[i], got.Data[i])
+				}
+			}
+		}
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
@@ -0,0 +1,138 @@
+package mat_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+// rankDeficient returns a 6x4 matrix whose third column is the sum of the first two.
+func rankDeficient() *mat.Mat[float64] {
+	a := mattest.Random[float64](rand.New(rand.NewSource(7)), 6, 4)
+	for i := 0; i < 6; i++ {
+		a.Data[i*4+2] = a.Data[i*4] + a.Data[i*4+1]
+	}
+	return a
+}
+
+// checkOrthonormal fails the test if the columns of q are not orthonormal.
+func checkOrthonormal(t *testing.T, q *mat.Mat[float64]) {
+	t.Helper()
+	qtq, _ := mat.Dot(mat.Transpose(q), q)
+	identity, _ := mat.Zeros[float64](q.N, q.N)
+	for i := 0; i < q.N; i++ {
+		identity.Data[i*q.N+i] = 1
+	}
+	mattest.AssertEqual(t, qtq, identity, mattest.Tolerance{Abs: 1e-14})
+}
+
+func TestGramSchmidt(t *testing.T) {
+	start := time.Now()
+
+	// Test case 1: Columns keep their order
+	a, _ := mat.New([][]float64{
+		{3, 1},
+		{4, 1},
+		{0, 1},
+	})
+	q := mat.GramSchmidt(a, 0)
+	if q.M != 3 || q.N != 2 {
+		t.Fatalf("Wrong dimensions. Want: 3x2, Got: %dx%d", q.M, q.N)
+	}
+	first := mat.SliceCols(q, 0, 1)
+	expected, _ := mat.New([][]float64{{0.6}, {0.8}, {0}})
+	mattest.AssertEqual(t, first, expected, mattest.Tolerance{Abs: 1e-15})
+	checkOrthonormal(t, q)
+
+	// Test case 2: Dependent columns are dropped
+	q = mat.GramSchmidt(rankDeficient(), 0)
+	if q.N != 3 {
+		t.Errorf("Wrong number of columns. Want: 3, Got: %d", q.N)
+	}
+	checkOrthonormal(t, q)
+
+	// Test case 3: Nearly dependent columns stay orthogonal thanks to reorthogonalization
+	eps := 1e-8
+	hilbertLike, _ := mat.New([][]float64{
+		{1, 1, 1},
+		{eps, 0, 0},
+		{0, eps, 0},
+		{0, 0, eps},
+	})
+	checkOrthonormal(t, mat.GramSchmidt(hilbertLike, 0))
+
+	// Test case 4: Zero matrix has no basis
+	zeros, _ := mat.Zeros[float64](3, 2)
+	if q := mat.GramSchmidt(zeros, 0); q != nil {
+		t.Errorf("Expected nil basis for zero matrix, got: %s", q)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestOrth(t *testing.T) {
+	start := time.Now()
+
+	a := rankDeficient()
+	q := mat.Orth(a, 0)
+	if q.M != 6 || q.N != 3 {
+		t.Fatalf("Wrong dimensions. Want: 6x3, Got: %dx%d", q.M, q.N)
+	}
+	checkOrthonormal(t, q)
+
+	// Projecting onto the basis leaves a unchanged
+	qta, _ := mat.Dot(mat.Transpose(q), a)
+	projected, _ := mat.Dot(q, qta)
+	mattest.AssertEqual(t, projected, a, mattest.Tolerance{Abs: 1e-14})
+
+	// A large tolerance treats the smaller directions as noise
+	scaled, _ := mat.New([][]float64{{1, 0}, {0, 1e-6}})
+	if q := mat.Orth(scaled, 1e-3); q.N != 1 {
+		t.Errorf("Wrong rank with tolerance 1e-3. Want: 1, Got: %d", q.N)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestNullSpace(t *testing.T) {
+	start := time.Now()
+
+	// Test case 1: Wide matrix of rank 3 has a 3-dimensional null space
+	a := mat.Transpose(rankDeficient())
+	n := mat.NullSpace(a, 0)
+	if n == nil || n.M != 6 || n.N != 3 {
+		t.Fatalf("Wrong null space dimensions. Want: 6x3, Got: %v", n)
+	}
+	checkOrthonormal(t, n)
+	an, _ := mat.Dot(a, n)
+	zeros, _ := mat.Zeros[float64](4, 3)
+	mattest.AssertEqual(t, an, zeros, mattest.Tolerance{Abs: 1e-14})
+
+	// Test case 2: Known kernel of a 2x3 matrix
+	b, _ := mat.New([][]float64{
+		{1, 1, 0},
+		{0, 0, 1},
+	})
+	n = mat.NullSpace(b, 0)
+	if n.N != 1 {
+		t.Fatalf("Wrong null space dimension. Want: 1, Got: %d", n.N)
+	}
+	if n.At(1, 1) < 0 {
+		n = mat.Scale(n, -1)
+	}
+	expected, _ := mat.New([][]float64{{math.Sqrt2 / 2}, {-math.Sqrt2 / 2}, {0}})
+	mattest.AssertEqual(t, n, expected, mattest.Tolerance{Abs: 1e-15})
+
+	// Test case 3: Full column rank
+	full := mattest.Random[float64](rand.New(rand.NewSource(8)), 6, 4)
+	if n := mat.NullSpace(full, 0); n != nil {
+		t.Errorf("Expected nil null space for full column rank, got: %s", n)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
@@ -0,0 +1,129 @@
+package mat_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+// quickConfig returns a deterministic testing/quick configuration running count checks.
+func quickConfig(count int) *quick.Config {
+	return &quick.Config{MaxCount: count, Rand: rand.New(rand.NewSource(1))}
+}
+
+// dim maps an arbitrary generated byte to a dimension in [1, limit].
+func dim(b uint8, limit int) int {
+	return int(b)%limit + 1
+}
+
+// dotReference multiplies with the textbook triple loop.
+func dotReference[T int | float64](a, b *mat.Mat[T]) *mat.Mat[T] {
+	res, _ := mat.Zeros[T](a.M, b.N)
+	for i := 0; i < a.M; i++ {
+		for j := 0; j < b.N; j++ {
+			var s T
+			for k := 0; k < a.N; k++ {
+				s += a.Data[i*a.N+k] * b.Data[k*b.N+j]
+			}
+			res.Data[i*res.N+j] = s
+		}
+	}
+	return res
+}
+
+func TestPropertyDotAssociative(t *testing.T) {
+	start := time.Now()
+
+	property := func(seed int64, m, k, l, n uint8) bool {
+		rng := rand.New(rand.NewSource(seed))
+		a := mattest.Random[float64](rng, dim(m, 40), dim(k, 40))
+		b := mattest.Random[float64](rng, a.N, dim(l, 40))
+		c := mattest.Random[float64](rng, b.N, dim(n, 40))
+
+		ab, _ := mat.Dot(a, b)
+		bc, _ := mat.Dot(b, c)
+		left, _ := mat.Dot(ab, c)
+		right, _ := mat.Dot(a, bc)
+
+		return mattest.EqualMatrixTol(left, right, mattest.Tolerance{Abs: 1e-10, Rel: 1e-10})
+	}
+
+	if err := quick.Check(property, quickConfig(50)); err != nil {
+		t.Error(err)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestPropertyDotTranspose(t *testing.T) {
+	start := time.Now()
+
+	// (AB)ᵀ = BᵀAᵀ holds exactly for integers
+	property := func(seed int64, m, k, n uint8) bool {
+		rng := rand.New(rand.NewSource(seed))
+		a := mattest.Random[int](rng, dim(m, 50), dim(k, 50))
+		b := mattest.Random[int](rng, a.N, dim(n, 50))
+
+		ab, _ := mat.Dot(a, b)
+		btat, _ := mat.Dot(mat.Transpose(b), mat.Transpose(a))
+
+		return mattest.EqualMatrixTol(mat.Transpose(ab), btat, mattest.Tolerance{})
+	}
+
+	if err := quick.Check(property, quickConfig(50)); err != nil {
+		t.Error(err)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestPropertyStrassen(t *testing.T) {
+	start := time.Now()
+
+	// Sizes from just past the crossover to well beyond it, so that the Strassen
+	// recursion runs more than one level deep and pads odd shapes.
+	property := func(seed int64, m, k, n uint16) bool {
+		rng := rand.New(rand.NewSource(seed))
+		a := mattest.Random[int](rng, 128+int(m)%200, 128+int(k)%200)
+		b := mattest.Random[int](rng, a.N, 128+int(n)%200)
+
+		result, err := mat.Dot(a, b)
+		if err != nil {
+			return false
+		}
+		return mattest.AssertEqual(t, result, dotReference(a, b), mattest.Tolerance{})
+	}
+
+	if err := quick.Check(property, quickConfig(4)); err != nil {
+		t.Error(err)
+	}
+
+	// Floats agree up to rounding
+	rng := rand.New(rand.NewSource(5))
+	a := mattest.RandomConditioned[float64](rng, 300, 257, 10)
+	b := mattest.Random[float64](rng, 257, 140)
+	result, _ := mat.Dot(a, b)
+	mattest.AssertEqual(t, result, dotReference(a, b), mattest.Tolerance{Abs: 1e-12, Rel: 1e-9})
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestPropertyTransposeInvolution(t *testing.T) {
+	start := time.Now()
+
+	property := func(seed int64, m, n uint8) bool {
+		a := mattest.Random[float32](rand.New(rand.NewSource(seed)), dim(m, 64), dim(n, 64))
+		return mattest.EqualMatrixTol(mat.Transpose(mat.Transpose(a)), a, mattest.Tolerance{})
+	}
+
+	if err := quick.Check(property, quickConfig(100)); err != nil {
+		t.Error(err)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
@@ -0,0 +1,194 @@
+package mat_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+func TestSumAxis(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.New([][]int{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+
+	testCases := []struct {
+		axis     mat.Axis
+		expected [][]int
+	}{
+		{mat.AxisAll, [][]int{{21}}},
+		{mat.AxisRows, [][]int{{5, 7, 9}}},
+		{mat.AxisCols, [][]int{{6}, {15}}},
+	}
+
+	for _, tc := range testCases {
+		expected, _ := mat.New(tc.expected)
+		result := mat.SumAxis(m, tc.axis)
+		if !mattest.EqualMatrix(result, expected) {
+			t.Errorf("Wrong result in SumAxis along %s. Want: %s\nGot: %s", tc.axis, expected, result)
+		}
+	}
+
+	// Invalid axis (should panic)
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("SumAxis did not panic on an invalid axis")
+			}
+		}()
+		mat.SumAxis(m, mat.Axis(42))
+	}()
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestSumAxisPairwise(t *testing.T) {
+	start := time.Now()
+
+	// A long float32 column where naive accumulation drifts noticeably
+	const n = 1 << 20
+	m, _ := mat.Zeros[float32](n, 1)
+	for i := range m.Data {
+		m.Data[i] = 0.1
+	}
+
+	exact := float64(float32(0.1)) * n
+	result := float64(mat.SumAxis(m, mat.AxisRows).Data[0])
+
+	// Pairwise summation keeps the relative error within a few ulps times log2(n)
+	if relErr := math.Abs(result-exact) / exact; relErr > 1e-5 {
+		t.Errorf("Pairwise float32 sum too inaccurate. Want: %f, Got: %f (relative error %g)", exact, result, relErr)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestMeanVarStd(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.New([][]float64{
+		{1, 2},
+		{3, 4},
+		{5, 12},
+	})
+
+	// Test case 1: Column means
+	expected, _ := mat.New([][]float64{{3, 6}})
+	result := mat.MeanAxis(m, mat.AxisRows)
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in MeanAxis. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 2: Population and sample variance of columns
+	expected, _ = mat.New([][]float64{{8.0 / 3, 56.0 / 3}})
+	result = mat.VarAxis(m, mat.AxisRows, 0)
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in VarAxis (ddof 0). Want: %s\nGot: %s", expected, result)
+	}
+
+	expected, _ = mat.New([][]float64{{4, 28}})
+	result = mat.VarAxis(m, mat.AxisRows, 1)
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in VarAxis (ddof 1). Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 3: Standard deviation of rows
+	expected, _ = mat.New([][]float64{{0.5}, {0.5}, {3.5}})
+	result = mat.StdAxis(m, mat.AxisCols, 0)
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in StdAxis. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 4: Integer mean doesn't truncate
+	ints, _ := mat.New([][]int{{1, 2}})
+	if mean := mat.MeanAxis(ints, mat.AxisAll).Data[0]; mean != 1.5 {
+		t.Errorf("Wrong result in integer MeanAxis. Want: 1.5, Got: %f", mean)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestProdMinMaxAxis(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.New([][]int{
+		{3, -1, 2},
+		{1, 5, 2},
+	})
+
+	expected, _ := mat.New([][]int{{3, -5, 4}})
+	result := mat.ProdAxis(m, mat.AxisRows)
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in ProdAxis. Want: %s\nGot: %s", expected, result)
+	}
+
+	expected, _ = mat.New([][]int{{-1}, {1}})
+	result = mat.MinAxis(m, mat.AxisCols)
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in MinAxis. Want: %s\nGot: %s", expected, result)
+	}
+
+	expected, _ = mat.New([][]int{{5}})
+	result = mat.MaxAxis(m, mat.AxisAll)
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in MaxAxis. Want: %s\nGot: %s", expected, result)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestArgMinArgMaxAxis(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.New([][]float64{
+		{3, -1, 2},
+		{1, 5, 2},
+	})
+
+	expected, _ := mat.New([][]int{{1, 0, 0}})
+	result := mat.ArgMinAxis(m, mat.AxisRows)
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in ArgMinAxis. Want: %s\nGot: %s", expected, result)
+	}
+
+	expected, _ = mat.New([][]int{{0}, {1}})
+	result = mat.ArgMaxAxis(m, mat.AxisCols)
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in ArgMaxAxis. Want: %s\nGot: %s", expected, result)
+	}
+
+	if idx := mat.ArgMaxAxis(m, mat.AxisAll).Data[0]; idx != 4 {
+		t.Errorf("Wrong flat index in ArgMaxAxis. Want: 4, Got: %d", idx)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestAnyAllAxis(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.New([][]int{
+		{0, 1, 1},
+		{0, 0, 1},
+	})
+
+	expected, _ := mat.New([][]int{{0, 1, 1}})
+	result := mat.AnyAxis(m, mat.AxisRows)
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in AnyAxis. Want: %s\nGot: %s", expected, result)
+	}
+
+	expected, _ = mat.New([][]int{{0, 0, 1}})
+	result = mat.AllAxis(m, mat.AxisRows)
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in AllAxis. Want: %s\nGot: %s", expected, result)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
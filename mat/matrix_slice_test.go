@@ -0,0 +1,221 @@
+package mat_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+func TestSliceCols(t *testing.T) {
+	start := time.Now()
+
+	m, err := mat.New([][]int{
+		{1, 2, 3, 4},
+		{5, 6, 7, 8},
+	})
+	if err != nil {
+		t.Errorf("Error creating matrix: %v", err)
+	}
+
+	expected, err := mat.New([][]int{
+		{2, 3},
+		{6, 7},
+	})
+	if err != nil {
+		t.Errorf("Error creating matrix: %v", err)
+	}
+
+	result := mat.SliceCols(m, 1, 3)
+
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in SliceCols. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 2: Verify deep copy
+	result.Data[0] = 999
+	if m.Data[1] == 999 {
+		t.Errorf("SliceCols did not perform a deep copy")
+	}
+
+	// Test case 3: Empty range (should panic)
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("SliceCols did not panic when start equaled end")
+			}
+		}()
+		mat.SliceCols(m, 2, 2)
+	}()
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestSub(t *testing.T) {
+	start := time.Now()
+
+	m, err := mat.New([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+	})
+	if err != nil {
+		t.Errorf("Error creating matrix: %v", err)
+	}
+
+	expected, err := mat.New([][]float64{
+		{5, 6},
+		{8, 9},
+	})
+	if err != nil {
+		t.Errorf("Error creating matrix: %v", err)
+	}
+
+	result := mat.Sub(m, 1, 3, 1, 3)
+
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in Sub. Want: %s\nGot: %s", expected, result)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Sub did not panic on an empty column range")
+			}
+		}()
+		mat.Sub(m, 0, 2, 2, 1)
+	}()
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestSelectRowsAndCols(t *testing.T) {
+	start := time.Now()
+
+	m, err := mat.New([][]int{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+	})
+	if err != nil {
+		t.Errorf("Error creating matrix: %v", err)
+	}
+
+	// Test case 1: Arbitrary row order with repetition
+	expectedRows, _ := mat.New([][]int{
+		{7, 8, 9},
+		{1, 2, 3},
+		{7, 8, 9},
+	})
+	result := mat.SelectRows(m, []int{2, 0, 2})
+	if !mattest.EqualMatrix(result, expectedRows) {
+		t.Errorf("Wrong result in SelectRows. Want: %s\nGot: %s", expectedRows, result)
+	}
+
+	// Test case 2: Arbitrary column order
+	expectedCols, _ := mat.New([][]int{
+		{3, 1},
+		{6, 4},
+		{9, 7},
+	})
+	result = mat.SelectCols(m, []int{2, 0})
+	if !mattest.EqualMatrix(result, expectedCols) {
+		t.Errorf("Wrong result in SelectCols. Want: %s\nGot: %s", expectedCols, result)
+	}
+
+	// Test case 3: Boolean masks
+	expectedMasked, _ := mat.New([][]int{
+		{1, 3},
+		{7, 9},
+	})
+	result = mat.MaskCols(mat.MaskRows(m, []bool{true, false, true}), []bool{true, false, true})
+	if !mattest.EqualMatrix(result, expectedMasked) {
+		t.Errorf("Wrong result in MaskRows/MaskCols. Want: %s\nGot: %s", expectedMasked, result)
+	}
+
+	// Test case 4: Out of range index (should panic)
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("SelectRows did not panic on an out of range index")
+			}
+		}()
+		mat.SelectRows(m, []int{3})
+	}()
+
+	// Test case 5: Mask with wrong length (should panic)
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("MaskCols did not panic on a mask of wrong length")
+			}
+		}()
+		mat.MaskCols(m, []bool{true})
+	}()
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestSetSubmatrix(t *testing.T) {
+	start := time.Now()
+
+	dst, _ := mat.Zeros[int](3, 3)
+	src, _ := mat.New([][]int{
+		{1, 2},
+		{3, 4},
+	})
+
+	// Test case 1: Write a block
+	expected, _ := mat.New([][]int{
+		{0, 0, 0},
+		{0, 1, 2},
+		{0, 3, 4},
+	})
+	mat.SetSub(dst, 1, 1, src)
+	if !mattest.EqualMatrix(dst, expected) {
+		t.Errorf("Wrong result in SetSub. Want: %s\nGot: %s", expected, dst)
+	}
+
+	// Test case 2: Write rows and columns by index
+	row, _ := mat.New([][]int{{7, 8, 9}})
+	mat.SetRows(dst, []int{0}, row)
+	col, _ := mat.New([][]int{{5}, {5}, {5}})
+	mat.SetCols(dst, []int{2}, col)
+
+	expected, _ = mat.New([][]int{
+		{7, 8, 5},
+		{0, 1, 5},
+		{0, 3, 5},
+	})
+	if !mattest.EqualMatrix(dst, expected) {
+		t.Errorf("Wrong result in SetRows/SetCols. Want: %s\nGot: %s", expected, dst)
+	}
+
+	// Test case 3: Block that doesn't fit (should panic)
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("SetSub did not panic when the block did not fit")
+			}
+		}()
+		mat.SetSub(dst, 2, 2, src)
+	}()
+
+	// Test case 4: Out of range row index (should panic without writing any row)
+	rows, _ := mat.New([][]int{{1, 1, 1}, {2, 2, 2}})
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("SetRows did not panic on an out of range index")
+			}
+		}()
+		mat.SetRows(dst, []int{1, 3}, rows)
+	}()
+	if !mattest.EqualMatrix(dst, expected) {
+		t.Errorf("SetRows modified the matrix before panicking. Want: %s\nGot: %s", expected, dst)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
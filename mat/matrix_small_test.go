@@ -0,0 +1,143 @@
+package mat_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+// detReference calculates the determinant by Laplace expansion along the first row.
+func detReference(m *mat.Mat[float64]) float64 {
+	if m.M == 1 {
+		return m.Data[0]
+	}
+
+	var det float64
+	sign := 1.0
+	for j := 1; j <= m.N; j++ {
+		cols := make([]int, 0, m.N-1)
+		for c := range m.N {
+			if c != j-1 {
+				cols = append(cols, c)
+			}
+		}
+		minor := mat.SelectCols(mat.SliceRows(m, 1, m.M), cols)
+		det += sign * m.At(1, j) * detReference(minor)
+		sign = -sign
+	}
+	return det
+}
+
+func TestFixedSizeMatrices(t *testing.T) {
+	start := time.Now()
+
+	rng := rand.New(rand.NewSource(1))
+	tol := mattest.Tolerance{Abs: 1e-12, Rel: 1e-12}
+
+	for n := 2; n <= 4; n++ {
+		a, b := mattest.Random[float64](rng, n, n), mattest.Random[float64](rng, n, n)
+		wantProd, _ := mat.Dot(a, b)
+		wantDet := detReference(a)
+		ident, _ := mat.Zeros[float64](n, n)
+		for i := range n {
+			ident.Data[i*(n+1)] = 1
+		}
+
+		var gotProd, gotInv, gotMat *mat.Mat[float64]
+		var gotVec []float64
+		var gotDet float64
+		var err error
+		switch n {
+		case 2:
+			fa, _ := mat.Mat2From(a)
+			fb, _ := mat.Mat2From(b)
+			gotProd, gotDet, gotMat = fa.Mul(fb).Mat(), fa.Det(), fa.Transpose().Mat()
+			v := fa.MulVec([2]float64{1, 2})
+			gotVec = v[:]
+			var inv mat.Mat2[float64]
+			inv, err = fa.Inverse()
+			gotInv = fa.Mul(inv).Mat()
+			if mat.Identity2[float64]().Mul(fa) != fa {
+				t.Errorf("Expected the identity to leave a 2x2 matrix unchanged")
+			}
+		case 3:
+			fa, _ := mat.Mat3From(a)
+			fb, _ := mat.Mat3From(b)
+			gotProd, gotDet, gotMat = fa.Mul(fb).Mat(), fa.Det(), fa.Transpose().Mat()
+			v := fa.MulVec([3]float64{1, 2, 3})
+			gotVec = v[:]
+			var inv mat.Mat3[float64]
+			inv, err = fa.Inverse()
+			gotInv = fa.Mul(inv).Mat()
+			if mat.Identity3[float64]().Mul(fa) != fa {
+				t.Errorf("Expected the identity to leave a 3x3 matrix unchanged")
+			}
+		case 4:
+			fa, _ := mat.Mat4From(a)
+			fb, _ := mat.Mat4From(b)
+			gotProd, gotDet, gotMat = fa.Mul(fb).Mat(), fa.Det(), fa.Transpose().Mat()
+			v := fa.MulVec([4]float64{1, 2, 3, 4})
+			gotVec = v[:]
+			var inv mat.Mat4[float64]
+			inv, err = fa.Inverse()
+			gotInv = fa.Mul(inv).Mat()
+			if mat.Identity4[float64]().Mul(fa) != fa {
+				t.Errorf("Expected the identity to leave a 4x4 matrix unchanged")
+			}
+		}
+
+		// Test case 1: Products, transposes and determinants agree with the general code
+		mattest.AssertEqual(t, gotProd, wantProd, tol)
+		mattest.AssertEqual(t, gotMat, mat.Transpose(a), tol)
+		if !mattest.Close(gotDet, wantDet, tol) {
+			t.Errorf("Wrong %dx%d determinant. Want: %g, Got: %g", n, n, wantDet, gotDet)
+		}
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = float64(i + 1)
+		}
+		wantVec, _ := mat.Dot(a, &mat.Mat[float64]{M: n, N: 1, Data: x})
+		mattest.AssertEqual(t, &mat.Mat[float64]{M: n, N: 1, Data: gotVec}, wantVec, tol)
+
+		// Test case 2: The inverse undoes the matrix
+		if err != nil {
+			t.Fatalf("Unexpected error inverting a %dx%d matrix: %v", n, n, err)
+		}
+		mattest.AssertEqual(t, gotInv, ident, mattest.Tolerance{Abs: 1e-9})
+	}
+
+	// Test case 3: Singular matrices and wrong shapes
+	if _, err := (mat.Mat3[float64]{1, 2, 3, 2, 4, 6, 0, 1, 0}).Inverse(); !errors.Is(err, mat.ErrSingular) {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+	if _, err := (mat.Mat4[float32]{}).Inverse(); !errors.Is(err, mat.ErrSingular) {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+	var shapeErr *mat.ShapeError
+	if _, err := mat.Mat3From(mat.Transpose(mattest.Random[float64](rng, 3, 4))); !errors.As(err, &shapeErr) {
+		t.Errorf("Expected a shape error, got %v", err)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestFixedSizeNoAllocations(t *testing.T) {
+	start := time.Now()
+
+	a := mat.Mat4[float64]{2, 0, 0, 1, 0, 3, 0, 2, 0, 0, 4, 3, 0, 0, 0, 1}
+	allocs := testing.AllocsPerRun(100, func() {
+		b := a.Mul(a).Transpose()
+		inv, _ := b.Inverse()
+		_ = inv.MulVec([4]float64{1, 2, 3, 1})
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %g", allocs)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
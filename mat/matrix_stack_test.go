@@ -0,0 +1,218 @@
+package mat_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+func TestStack(t *testing.T) {
+	start := time.Now()
+
+	a, _ := mat.New([][]int{
+		{1, 2},
+		{3, 4},
+	})
+	b, _ := mat.New([][]int{
+		{5},
+		{6},
+	})
+
+	// Test case 1: Horizontal stacking
+	expected, _ := mat.New([][]int{
+		{1, 2, 5},
+		{3, 4, 6},
+	})
+	result, err := mat.HStack(a, b)
+	if err != nil {
+		t.Errorf("Error stacking matrices: %v", err)
+	}
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in HStack. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 2: Vertical stacking
+	c, _ := mat.New([][]int{{7, 8}})
+	expected, _ = mat.New([][]int{
+		{1, 2},
+		{3, 4},
+		{7, 8},
+	})
+	result, err = mat.VStack(a, c)
+	if err != nil {
+		t.Errorf("Error stacking matrices: %v", err)
+	}
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in VStack. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 3: Mismatched dimensions return a ShapeError
+	_, err = mat.VStack(a, b)
+	var shapeErr *mat.ShapeError
+	if !errors.As(err, &shapeErr) {
+		t.Errorf("Expected a ShapeError for mismatched VStack, got %v", err)
+	}
+	_, err = mat.HStack(a, c)
+	if !errors.As(err, &shapeErr) {
+		t.Errorf("Expected a ShapeError for mismatched HStack, got %v", err)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestBlockDiag(t *testing.T) {
+	start := time.Now()
+
+	a, _ := mat.New([][]float64{{1, 2}})
+	b, _ := mat.New([][]float64{
+		{3},
+		{4},
+	})
+
+	expected, _ := mat.New([][]float64{
+		{1, 2, 0},
+		{0, 0, 3},
+		{0, 0, 4},
+	})
+
+	result, err := mat.BlockDiag(a, b)
+	if err != nil {
+		t.Errorf("Error building block diagonal matrix: %v", err)
+	}
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in BlockDiag. Want: %s\nGot: %s", expected, result)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestReshapeAndFlatten(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.New([][]int{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+
+	// Test case 1: Explicit dimensions
+	expected, _ := mat.New([][]int{
+		{1, 2},
+		{3, 4},
+		{5, 6},
+	})
+	result, err := mat.Reshape(m, 3, 2)
+	if err != nil {
+		t.Errorf("Error reshaping matrix: %v", err)
+	}
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in Reshape. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 2: Inferred dimension
+	result, err = mat.Reshape(m, -1, 1)
+	if err != nil {
+		t.Errorf("Error reshaping matrix: %v", err)
+	}
+	if result.M != 6 || result.N != 1 {
+		t.Errorf("Wrong dimensions after Reshape. Want: 6x1, Got: %dx%d", result.M, result.N)
+	}
+
+	// Test case 3: Incompatible size
+	_, err = mat.Reshape(m, 4, 2)
+	var shapeErr *mat.ShapeError
+	if !errors.As(err, &shapeErr) {
+		t.Errorf("Expected a ShapeError for invalid Reshape, got %v", err)
+	}
+
+	// Test case 4: Dimensions whose product wraps around to the number of elements
+	if math.MaxInt == math.MaxInt64 {
+		var wrapCols uint64 = 6148914691236517206
+		_, err = mat.Reshape(m, 9, int(wrapCols))
+		if !errors.As(err, &shapeErr) {
+			t.Errorf("Expected a ShapeError for Reshape with overflowing dimensions, got %v", err)
+		}
+	}
+
+	// Test case 5: Flatten
+	expected, _ = mat.New([][]int{{1, 2, 3, 4, 5, 6}})
+	result = mat.Flatten(m)
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in Flatten. Want: %s\nGot: %s", expected, result)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestTileAndRepeat(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.New([][]int{
+		{1, 2},
+		{3, 4},
+	})
+
+	// Test case 1: Tile
+	expected, _ := mat.New([][]int{
+		{1, 2, 1, 2, 1, 2},
+		{3, 4, 3, 4, 3, 4},
+		{1, 2, 1, 2, 1, 2},
+		{3, 4, 3, 4, 3, 4},
+	})
+	result, err := mat.Tile(m, 2, 3)
+	if err != nil {
+		t.Errorf("Error tiling matrix: %v", err)
+	}
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in Tile. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 2: Repeat rows
+	expected, _ = mat.New([][]int{
+		{1, 2},
+		{1, 2},
+		{3, 4},
+		{3, 4},
+	})
+	result, err = mat.Repeat(m, 2, mat.AxisRows)
+	if err != nil {
+		t.Errorf("Error repeating matrix: %v", err)
+	}
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in Repeat along rows. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 3: Repeat columns
+	expected, _ = mat.New([][]int{
+		{1, 1, 2, 2},
+		{3, 3, 4, 4},
+	})
+	result, err = mat.Repeat(m, 2, mat.AxisCols)
+	if err != nil {
+		t.Errorf("Error repeating matrix: %v", err)
+	}
+	if !mattest.EqualMatrix(result, expected) {
+		t.Errorf("Wrong result in Repeat along columns. Want: %s\nGot: %s", expected, result)
+	}
+
+	// Test case 4: Invalid repetitions
+	_, err = mat.Tile(m, 0, 1)
+	var shapeErr *mat.ShapeError
+	if !errors.As(err, &shapeErr) {
+		t.Errorf("Expected a ShapeError for invalid Tile, got %v", err)
+	}
+
+	// Test case 5: Results too large to allocate
+	if _, err = mat.Tile(m, math.MaxInt/2, 2); !errors.As(err, &shapeErr) {
+		t.Errorf("Expected a ShapeError for Tile with overflowing dimensions, got %v", err)
+	}
+	if _, err = mat.Repeat(m, math.MaxInt/2, mat.AxisRows); !errors.As(err, &shapeErr) {
+		t.Errorf("Expected a ShapeError for Repeat with overflowing dimensions, got %v", err)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
@@ -0,0 +1,169 @@
+package mat_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+// The structured types all satisfy the common interface
+var (
+	_ mat.Matrix[float64] = (*mat.Mat[float64])(nil)
+	_ mat.Matrix[float64] = (*mat.TriDense[float64])(nil)
+	_ mat.Matrix[float64] = (*mat.SymDense[float64])(nil)
+	_ mat.Matrix[float64] = (*mat.DiagDense[float64])(nil)
+	_ mat.Matrix[float64] = (*mat.BandDense[float64])(nil)
+	_ mat.Matrix[float64] = (*mat.CSR[float64])(nil)
+)
+
+func expectPanic(t *testing.T, name string, fn func()) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected %s to panic, but it did not", name)
+		}
+	}()
+	fn()
+}
+
+func TestTriDense(t *testing.T) {
+	start := time.Now()
+
+	rng := rand.New(rand.NewSource(1))
+	tol := mattest.Tolerance{Abs: 1e-12, Rel: 1e-12}
+
+	for _, upper := range []bool{true, false} {
+		dense := mattest.RandomTriangular[float64](rng, 6, upper)
+
+		// Test case 1: Only the triangle is stored, and the dense copy matches
+		tri, err := mat.TriDenseFrom(dense, upper)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(tri.Data) != 21 {
+			t.Errorf("Expected 21 stored elements, got %d", len(tri.Data))
+		}
+		if !mattest.EqualMatrix(tri.Dense(), dense) {
+			t.Errorf("Wrong dense copy. Want: %s\nGot: %s", dense, tri.Dense())
+		}
+		if r, c := tri.Dims(); r != 6 || c != 6 || tri.At(2, 5) != dense.At(2, 5) {
+			t.Errorf("Wrong dimensions or elements for triangular matrix")
+		}
+
+		// Test case 2: Triangular solve agrees with the product
+		x := mattest.Random[float64](rng, 6, 3)
+		b, _ := mat.Dot(dense, x)
+		got, err := mat.SolveTri(tri, b)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		mattest.AssertEqual(t, got, x, tol)
+
+		// Test case 3: The other triangle can't be set or copied in
+		expectPanic(t, "SetTri outside the triangle", func() {
+			if upper {
+				tri.SetTri(3, 1, 1)
+			} else {
+				tri.SetTri(1, 3, 1)
+			}
+		})
+		if _, err := mat.TriDenseFrom(dense, !upper); err == nil {
+			t.Errorf("Expected an error for a matrix that isn't triangular")
+		}
+	}
+
+	// Test case 4: A zero on the diagonal is singular
+	tri, _ := mat.NewTriDense[float64](3, false)
+	tri.SetTri(1, 1, 1)
+	tri.SetTri(3, 3, 1)
+	b, _ := mat.Ones[float64](3, 1)
+	if _, err := mat.SolveTri(tri, b); !errors.Is(err, mat.ErrSingular) {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+	expectPanic(t, "At out of range", func() { tri.At(4, 1) })
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestSymDense(t *testing.T) {
+	start := time.Now()
+
+	rng := rand.New(rand.NewSource(2))
+	cov := mattest.RandomSPD[float64](rng, 5, 10)
+
+	// Test case 1: Half of the matrix is stored and both triangles read it
+	sym, err := mat.SymDenseFrom(cov)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sym.Data) != 15 {
+		t.Errorf("Expected 15 stored elements, got %d", len(sym.Data))
+	}
+	if !mattest.EqualMatrix(sym.Dense(), cov) {
+		t.Errorf("Wrong dense copy. Want: %s\nGot: %s", cov, sym.Dense())
+	}
+
+	// Test case 2: Setting one element sets its mirror
+	sym.SetSym(4, 2, 7)
+	if sym.At(2, 4) != 7 || sym.At(4, 2) != 7 {
+		t.Errorf("Expected both (2, 4) and (4, 2) to be 7, got %g and %g", sym.At(2, 4), sym.At(4, 2))
+	}
+
+	// Test case 3: Non-symmetric and non-square matrices are rejected
+	cov.Data[1] += 1
+	if _, err := mat.SymDenseFrom(cov); err == nil {
+		t.Errorf("Expected an error for a non-symmetric matrix")
+	}
+	var shapeErr *mat.ShapeError
+	if _, err := mat.SymDenseFrom(mattest.Random[float64](rng, 2, 3)); !errors.As(err, &shapeErr) {
+		t.Errorf("Expected a shape error, got %v", err)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestDiagDense(t *testing.T) {
+	start := time.Now()
+
+	d, _ := mat.NewDiagDense([]float64{2, -4, 0.5})
+	m, _ := mat.New([][]float64{{1, 2}, {3, 4}, {5, 6}})
+
+	// Test case 1: Dense copy and elements
+	want, _ := mat.New([][]float64{{2, 0, 0}, {0, -4, 0}, {0, 0, 0.5}})
+	if !mattest.EqualMatrix(d.Dense(), want) {
+		t.Errorf("Wrong dense copy. Want: %s\nGot: %s", want, d.Dense())
+	}
+
+	// Test case 2: Products scale rows and solves divide them
+	prod, err := mat.MulDiag(d, m)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	wantProd, _ := mat.Dot(want, m)
+	if !mattest.EqualMatrix(prod, wantProd) {
+		t.Errorf("Wrong product. Want: %s\nGot: %s", wantProd, prod)
+	}
+	x, err := mat.SolveDiag(d, prod)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !mattest.EqualMatrix(x, m) {
+		t.Errorf("Wrong solution. Want: %s\nGot: %s", m, x)
+	}
+
+	// Test case 3: Errors for zero diagonals and mismatched shapes
+	d.SetDiag(2, 0)
+	if _, err := mat.SolveDiag(d, m); !errors.Is(err, mat.ErrSingular) {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+	if _, err := mat.MulDiag(d, mat.Transpose(m)); err == nil {
+		t.Errorf("Expected an error for mismatched shapes")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
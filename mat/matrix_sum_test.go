@@ -5,8 +5,8 @@ import (
 	"testing"
 	"time"
 
-	"github.com/lattots/gonum/internal/util"
 	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
 )
 
 func TestMatrixSum(t *testing.T) {
@@ -43,7 +43,7 @@ func TestMatrixSum(t *testing.T) {
 
 	result := mat.Sum(m1, m2)
 
-	if !util.EqualMatrix(result, expected) {
+	if !mattest.EqualMatrix(result, expected) {
 		t.Errorf("Wrong result in matrix addition. Want: %s\nGot: %s", expected, result)
 	}
 
@@ -84,7 +84,7 @@ func TestMatrixSubtract(t *testing.T) {
 
 	result := mat.Subtract(m1, m2)
 
-	if !util.EqualMatrix(result, expected) {
+	if !mattest.EqualMatrix(result, expected) {
 		t.Errorf("Wrong result in matrix subtraction. Want: %s\nGot: %s", expected, result)
 	}
 
@@ -111,7 +111,7 @@ func TestMatrixSumRows(t *testing.T) {
 
 	result := mat.SumRows(m)
 
-	if !util.EqualMatrix(result, expected) {
+	if !mattest.EqualMatrix(result, expected) {
 		t.Errorf("Wrong result in column wise addition. Want: %s\nGot: %s", expected, result)
 	}
 
@@ -138,7 +138,7 @@ func TestMatrixSumColumns(t *testing.T) {
 
 	result := mat.SumColumns(m)
 
-	if !util.EqualMatrix(result, expected) {
+	if !mattest.EqualMatrix(result, expected) {
 		t.Errorf("Wrong result in row wise addition. Want: %s\nGot: %s", expected, result)
 	}
 
@@ -174,7 +174,7 @@ func TestMatrixAddRowVector(t *testing.T) {
 
 	result := mat.AddRowVector(m, row)
 
-	if !util.EqualMatrix(result, expected) {
+	if !mattest.EqualMatrix(result, expected) {
 		t.Errorf("Wrong result in AddRowVector. Want: %s\nGot: %s", expected, result)
 	}
 
@@ -228,7 +228,7 @@ func TestMatrixAddColVector(t *testing.T) {
 
 	result := mat.AddColVector(m, col)
 
-	if !util.EqualMatrix(result, expected) {
+	if !mattest.EqualMatrix(result, expected) {
 		t.Errorf("Wrong result in AddColVector. Want: %s\nGot: %s", expected, result)
 	}
 
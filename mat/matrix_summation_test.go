@@ -0,0 +1,146 @@
+package mat_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+// float32Eps is the machine epsilon (unit roundoff times two) of float32.
+const float32Eps = 1.0 / (1 << 23)
+
+func TestSummationErrorBounds(t *testing.T) {
+	start := time.Now()
+
+	const n = 1 << 20
+
+	rng := rand.New(rand.NewSource(1))
+	m, _ := mat.Zeros[float32](1, n)
+	ones, _ := mat.Ones[float32](n, 1)
+	var exact, absSum float64
+	for i := range m.Data {
+		m.Data[i] = float32(rng.Float64())
+		exact += float64(m.Data[i])
+		absSum += math.Abs(float64(m.Data[i]))
+	}
+
+	// Error bounds relative to the sum of absolute values, with a factor of 2 of slack
+	testCases := []struct {
+		summation mat.Summation
+		bound     float64
+	}{
+		{mat.PairwiseSummation, 2 * math.Log2(n) * float32Eps},
+		{mat.KahanSummation, 2 * 2 * float32Eps},
+		{mat.Float64Summation, 2 * float32Eps},
+	}
+
+	naiveErr := math.Abs(float64(mat.SumAxisWith(m, mat.AxisAll, mat.NaiveSummation).Data[0])-exact) / absSum
+
+	for _, tc := range testCases {
+		sumErr := math.Abs(float64(mat.SumAxisWith(m, mat.AxisAll, tc.summation).Data[0])-exact) / absSum
+		if sumErr > tc.bound {
+			t.Errorf("%s summation error %g exceeds bound %g", tc.summation, sumErr, tc.bound)
+		}
+		if sumErr >= naiveErr {
+			t.Errorf("%s summation error %g is not below naive error %g", tc.summation, sumErr, naiveErr)
+		}
+
+		// Dot products with a vector of ones must follow the same bounds
+		dotErr := math.Abs(float64(mat.VectorDotWith(m, ones, tc.summation))-exact) / absSum
+		if dotErr > tc.bound {
+			t.Errorf("%s VectorDot error %g exceeds bound %g", tc.summation, dotErr, tc.bound)
+		}
+
+		product, err := mat.DotWith(m, ones, tc.summation)
+		if err != nil {
+			t.Errorf("Error during matrix multiplication: %v", err)
+		}
+		matErr := math.Abs(float64(product.Data[0])-exact) / absSum
+		if matErr > tc.bound {
+			t.Errorf("%s Dot error %g exceeds bound %g", tc.summation, matErr, tc.bound)
+		}
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestKahanSummationCancellation(t *testing.T) {
+	start := time.Now()
+
+	// The large terms cancel exactly, plain and pairwise summation lose both ones
+	m, _ := mat.New([][]float64{{1, 1e100, 1, -1e100}})
+
+	if sum := mat.SumAxisWith(m, mat.AxisAll, mat.KahanSummation).Data[0]; sum != 2 {
+		t.Errorf("Wrong result in compensated summation. Want: 2, Got: %g", sum)
+	}
+	if sum := mat.SumAxisWith(m, mat.AxisAll, mat.NaiveSummation).Data[0]; sum != 0 {
+		t.Errorf("Expected naive summation to cancel to 0, Got: %g", sum)
+	}
+
+	// Test case 2: Products above the Strassen threshold still follow the strategy
+	n := mat.StrassenThreshold[float64]() + 2
+	a, _ := mat.Zeros[float64](n, n)
+	for i := range n {
+		copy(a.Data[i*n:], m.Data)
+	}
+	b, _ := mat.Ones[float64](n, n)
+	v, _ := mat.NewVec(b.Data[:n])
+	for _, tc := range []struct {
+		summation mat.Summation
+		want      float64
+	}{
+		{mat.KahanSummation, 2},
+		{mat.NaiveSummation, 0},
+	} {
+		product, err := mat.DotWith(a, b, tc.summation)
+		if err != nil {
+			t.Fatalf("Error during matrix multiplication: %v", err)
+		}
+		// AᵀA is read in place and accumulated the same way as from a copy
+		gram, _ := mat.DotWith(mat.T(a), a, tc.summation)
+		wantGram, _ := mat.DotWith(mat.Transpose(a), a, tc.summation)
+		mv, _ := mat.MulVecWith(a, v, tc.summation)
+		for i, x := range product.Data {
+			if x != tc.want {
+				t.Fatalf("%s DotWith element %d is %g, want %g", tc.summation, i, x, tc.want)
+			}
+		}
+		if mv.AtVec(n) != tc.want || mat.VectorDotWith(mat.SliceRows(a, 0, 1), mat.SliceCols(b, 0, 1), tc.summation) != tc.want {
+			t.Errorf("%s matrix-vector and vector products disagree with DotWith", tc.summation)
+		}
+		if !mattest.EqualMatrixTol(gram, wantGram, mattest.Tolerance{}) {
+			t.Errorf("%s DotWith of a lazy transpose differs from the copy", tc.summation)
+		}
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestSetSummation(t *testing.T) {
+	start := time.Now()
+
+	// Test case 1: Pairwise summation is the default, and the default applies to the
+	// functions without an explicit strategy
+	if mat.CurrentSummation() != mat.PairwiseSummation {
+		t.Errorf("Wrong default summation. Want: pairwise, Got: %s", mat.CurrentSummation())
+	}
+	defer mat.SetSummation(mat.SetSummation(mat.KahanSummation))
+	m, _ := mat.New([][]float64{{1, 1e100, 1, -1e100}})
+	if sum := mat.SumAxis(m, mat.AxisAll).Data[0]; sum != 2 {
+		t.Errorf("Wrong result with the default set to Kahan. Want: 2, Got: %g", sum)
+	}
+	if mat.CurrentSummation() != mat.KahanSummation {
+		t.Errorf("Wrong current summation. Want: kahan, Got: %s", mat.CurrentSummation())
+	}
+
+	// Test case 2: Invalid strategies panic
+	expectPanic(t, "SetSummation with an invalid strategy", func() { mat.SetSummation(mat.Summation(-1)) })
+	expectPanic(t, "DotWith with an invalid strategy", func() { mat.DotWith(m, mat.T(m), mat.Summation(9)) })
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
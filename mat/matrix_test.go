@@ -5,8 +5,8 @@ import (
 	"testing"
 	"time"
 
-	"github.com/lattots/gonum/internal/util"
 	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
 )
 
 func TestNew(t *testing.T) {
@@ -200,7 +200,7 @@ func TestMatrixScale(t *testing.T) {
 
 	result1 := mat.Scale(m1, scalar1)
 
-	if !util.EqualMatrix(result1, expected1) {
+	if !mattest.EqualMatrix(result1, expected1) {
 		t.Errorf("Wrong result in matrix scaling. Want: %s\nGot: %s", expected1, result1)
 	}
 
@@ -227,7 +227,7 @@ func TestMatrixScale(t *testing.T) {
 
 	result2 := mat.Scale(m2, scalar2)
 
-	if !util.EqualMatrix(result2, expected2) {
+	if !mattest.EqualMatrix(result2, expected2) {
 		t.Errorf("Wrong result in matrix scaling. Want: %s\nGot: %s", expected2, result2)
 	}
 
@@ -260,7 +260,7 @@ func TestMatrixAdd(t *testing.T) {
 
 	result1 := mat.Add(m1, scalar1)
 
-	if !util.EqualMatrix(result1, expected1) {
+	if !mattest.EqualMatrix(result1, expected1) {
 		t.Errorf("Wrong result in matrix scalar addition. Want: %s\nGot: %s", expected1, result1)
 	}
 
@@ -287,7 +287,7 @@ func TestMatrixAdd(t *testing.T) {
 
 	result2 := mat.Add(m2, scalar2)
 
-	if !util.EqualMatrix(result2, expected2) {
+	if !mattest.EqualMatrix(result2, expected2) {
 		t.Errorf("Wrong result in matrix scalar addition. Want: %s\nGot: %s", expected2, result2)
 	}
 
@@ -324,7 +324,7 @@ func TestMatrixMap(t *testing.T) {
 
 	result1 := mat.Map(m1, squareFn)
 
-	if !util.EqualMatrix(result1, expected1) {
+	if !mattest.EqualMatrix(result1, expected1) {
 		t.Errorf("Wrong result in matrix map (float square). Want: %s\nGot: %s", expected1, result1)
 	}
 
@@ -358,7 +358,7 @@ func TestMatrixMap(t *testing.T) {
 
 	result2 := mat.Map(m2, absFn)
 
-	if !util.EqualMatrix(result2, expected2) {
+	if !mattest.EqualMatrix(result2, expected2) {
 		t.Errorf("Wrong result in matrix map (int abs). Want: %s\nGot: %s", expected2, result2)
 	}
 
@@ -391,7 +391,7 @@ func TestMatrixTranspose(t *testing.T) {
 
 	result := mat.T(m)
 
-	if !util.EqualMatrix(result, expected) {
+	if !mattest.EqualMatrix(result, expected) {
 		t.Errorf("Wrong result in matrix transpose. Want: %s\nGot: %s", expected, result)
 	}
 
@@ -501,7 +501,7 @@ func TestSliceRows(t *testing.T) {
 	// Slice from row 1 to 3 (exclusive, so rows 1 and 2)
 	result1 := mat.SliceRows(m1, 1, 3)
 
-	if !util.EqualMatrix(result1, expected1) {
+	if !mattest.EqualMatrix(result1, expected1) {
 		t.Errorf("Wrong result in SliceRows (float64 middle slice). Want: %s\nGot: %s", expected1, result1)
 	}
 
@@ -529,7 +529,7 @@ func TestSliceRows(t *testing.T) {
 	// Slice from row 0 to 2 (exclusive, so rows 0 and 1)
 	result2 := mat.SliceRows(m2, 0, 2)
 
-	if !util.EqualMatrix(result2, expected2) {
+	if !mattest.EqualMatrix(result2, expected2) {
 		t.Errorf("Wrong result in SliceRows (int32 front slice). Want: %s\nGot: %s", expected2, result2)
 	}
 
@@ -0,0 +1,122 @@
+package mat_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+var _ mat.Matrix[float64] = mat.Transposed[float64]{}
+
+func TestLazyTranspose(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.New([][]int{{1, 2, 3}, {4, 5, 6}})
+
+	// Test case 1: The wrapper reads the original without copying
+	tr := mat.T(m)
+	if r, c := tr.Dims(); r != 3 || c != 2 {
+		t.Errorf("Expected 3x2 transpose, got %dx%d", r, c)
+	}
+	m.Data[5] = 60
+	if tr.At(3, 2) != 60 || tr.At(1, 2) != 4 {
+		t.Errorf("Transpose doesn't read the original: %v", mat.DenseOf(tr))
+	}
+
+	// Test case 2: Transposing twice gives back the original matrix
+	if back, ok := mat.T(tr).(*mat.Mat[int]); !ok || back != m {
+		t.Errorf("Expected T(T(m)) to be m, got %T", mat.T(tr))
+	}
+	if u := tr.(mat.Transposed[int]).Untranspose(); u != mat.Matrix[int](m) {
+		t.Errorf("Untranspose doesn't return the original")
+	}
+
+	// Test case 3: Copies of the lazy transpose match the materialized one
+	want := mat.Transpose(m)
+	if !mattest.EqualMatrix(mat.DenseOf(tr), want) || !mattest.EqualMatrix(mat.Transpose(mat.T(tr)), want) {
+		t.Errorf("Wrong copy of the transpose. Want: %s\nGot: %s", want, mat.DenseOf(tr))
+	}
+	if got := mat.Transpose(tr); !mattest.EqualMatrix(got, m) || &got.Data[0] == &m.Data[0] {
+		t.Errorf("Expected a copy of the original matrix, got %s", got)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestDotTransposed(t *testing.T) {
+	start := time.Now()
+
+	rng := rand.New(rand.NewSource(1))
+	tol := mattest.Tolerance{Abs: 1e-9, Rel: 1e-9}
+
+	// Sizes below and above the Strassen threshold
+	for _, n := range []int{7, 150} {
+		a := mattest.Random[float64](rng, n+3, n)
+		b := mattest.Random[float64](rng, n+5, n)
+		at, bt := mat.Transpose(a), mat.Transpose(b)
+
+		// Test case 1: AᵀA and ABᵀ through the lazy transpose
+		ata, err := mat.Dot(mat.T(a), a)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		want, _ := mat.Dot(at, a)
+		mattest.AssertEqual(t, ata, want, tol)
+
+		abt, err := mat.Dot(a, mat.T(b))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		want, _ = mat.Dot(a, bt)
+		mattest.AssertEqual(t, abt, want, tol)
+
+		// Test case 2: Every combination of flags in DotTrans
+		c := mattest.Random[float64](rng, n+3, n+2)
+		ct := mat.Transpose(c)
+		want, _ = mat.Dot(ct, a)
+		for _, c := range []struct {
+			transA, transB bool
+			a, b           mat.Matrix[float64]
+		}{
+			{false, false, ct, a},
+			{true, false, c, a},
+			{false, true, ct, at},
+			{true, true, c, at},
+			{false, false, mat.T(c), a},
+			{true, true, mat.T(ct), mat.T(a)},
+		} {
+			got, err := mat.DotTrans(c.transA, c.transB, c.a, c.b)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !mattest.AssertEqual(t, got, want, tol) {
+				t.Errorf("Wrong product for transA=%v, transB=%v", c.transA, c.transB)
+			}
+		}
+	}
+
+	// Test case 3: Integer products are exact
+	m, _ := mat.New([][]int{{1, 2}, {3, 4}, {5, 6}})
+	gram, _ := mat.Dot(mat.T(m), m)
+	wantGram, _ := mat.New([][]int{{35, 44}, {44, 56}})
+	if !mattest.EqualMatrix(gram, wantGram) {
+		t.Errorf("Wrong Gram matrix. Want: %s\nGot: %s", wantGram, gram)
+	}
+
+	// Test case 4: Shapes are checked after transposing
+	if _, err := mat.Dot(m, mat.T(m)); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := mat.DotTrans(true, false, m, mat.T(m)); err == nil {
+		t.Errorf("Expected an error for mismatched shapes")
+	}
+	if _, err := mat.Dot(mat.T(m), mat.T(m)); err == nil {
+		t.Errorf("Expected an error for mismatched shapes")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
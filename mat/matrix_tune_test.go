@@ -0,0 +1,131 @@
+package mat_test
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+func TestStrassenThresholdOverride(t *testing.T) {
+	start := time.Now()
+
+	rng := rand.New(rand.NewSource(1))
+	m1 := mattest.Random[int](rng, 40, 33)
+	m2 := mattest.Random[int](rng, 33, 37)
+	expected, _ := mat.Dot(m1, m2)
+
+	// Test case 1: A tiny threshold recurses all the way down and gives the same result
+	prev := mat.SetStrassenThreshold[int](2)
+	defer mat.SetStrassenThreshold[int](prev)
+	if mat.StrassenThreshold[int]() != 2 {
+		t.Errorf("Wrong threshold after override. Want: 2, Got: %d", mat.StrassenThreshold[int]())
+	}
+	result, _ := mat.Dot(m1, m2)
+	mattest.AssertEqual(t, result, expected, mattest.Tolerance{})
+
+	// Test case 2: Overrides are per type
+	if mat.StrassenThreshold[int32]() == 2 {
+		t.Errorf("Override for int changed the threshold of int32")
+	}
+
+	// Test case 3: Never using Strassen
+	mat.SetStrassenThreshold[int](mat.NoStrassen)
+	result, _ = mat.Dot(m1, m2)
+	mattest.AssertEqual(t, result, expected, mattest.Tolerance{})
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic for invalid threshold, but got none")
+		}
+	}()
+	mat.SetStrassenThreshold[int](1)
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestTuningFile(t *testing.T) {
+	start := time.Now()
+
+	path := filepath.Join(t.TempDir(), "gonum", "tuning.json")
+	cores := runtime.GOMAXPROCS(0)
+
+	// Test case 1: Without LoadTuning the environment has no effect
+	os.WriteFile(path+".env", []byte(fmt.Sprintf(`{"tunings":[{"cores":%d,"thresholds":{"float32":77}}]}`, cores)), 0o644)
+	t.Setenv(mat.TuningEnv, path+".env")
+	if result := mat.StrassenThreshold[float32](); result != 128 {
+		t.Errorf("Threshold read from the environment. Want: 128, Got: %d", result)
+	}
+
+	// Test case 2: Tunings for other core counts are kept and ignored
+	if err := mat.SaveTuning(path, mat.Tuning{Cores: cores + 1, Thresholds: map[string]int{"float32": 999}}); err != nil {
+		t.Fatalf("Error saving tuning: %v", err)
+	}
+	if err := mat.SaveTuning(path, mat.Tuning{Cores: cores, Thresholds: map[string]int{"float32": 300}}); err != nil {
+		t.Fatalf("Error saving tuning: %v", err)
+	}
+	if err := mat.LoadTuning(path); err != nil {
+		t.Fatalf("Error loading tuning: %v", err)
+	}
+	if result := mat.StrassenThreshold[float32](); result != 300 {
+		t.Errorf("Wrong tuned threshold. Want: 300, Got: %d", result)
+	}
+
+	// Test case 3: Overrides win over the file
+	prev := mat.SetStrassenThreshold[float32](64)
+	if result := mat.StrassenThreshold[float32](); result != 64 {
+		t.Errorf("Wrong threshold with override. Want: 64, Got: %d", result)
+	}
+	mat.SetStrassenThreshold[float32](prev)
+
+	// Test case 4: Saving again replaces the entry for the same core count
+	mat.SaveTuning(path, mat.Tuning{Cores: cores, Thresholds: map[string]int{"float32": 200}})
+	mat.LoadTuning(path)
+	if result := mat.StrassenThreshold[float32](); result != 200 {
+		t.Errorf("Wrong tuned threshold after saving again. Want: 200, Got: %d", result)
+	}
+
+	// Test case 5: Invalid files are rejected whatever the core count of the entry, and a
+	// file without a matching entry resets to the default
+	for _, c := range []int{cores, cores + 1} {
+		os.WriteFile(path, []byte(fmt.Sprintf(`{"tunings":[{"cores":%d,"thresholds":{"complex128":4}}]}`, c)), 0o644)
+		if err := mat.LoadTuning(path); err == nil {
+			t.Errorf("Expected error loading unknown element type for %d cores, but got nil", c)
+		}
+	}
+	if result := mat.StrassenThreshold[float32](); result != 200 {
+		t.Errorf("Invalid file changed the tuned threshold. Want: 200, Got: %d", result)
+	}
+	os.WriteFile(path, []byte(`{"tunings":[]}`), 0o644)
+	if err := mat.LoadTuning(path); err != nil {
+		t.Fatalf("Error loading empty tuning: %v", err)
+	}
+	if result := mat.StrassenThreshold[float32](); result != 128 {
+		t.Errorf("Wrong default threshold. Want: 128, Got: %d", result)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestTuneStrassen(t *testing.T) {
+	start := time.Now()
+
+	tuning := mat.TuneStrassen(64)
+	if tuning.Cores != runtime.GOMAXPROCS(0) {
+		t.Errorf("Wrong core count. Want: %d, Got: %d", runtime.GOMAXPROCS(0), tuning.Cores)
+	}
+	for _, name := range []string{"int", "int8", "int16", "int32", "int64", "float32", "float64"} {
+		n, ok := tuning.Thresholds[name]
+		if !ok || n < 32 || n > 64 && n != mat.NoStrassen {
+			t.Errorf("Invalid threshold for %s: %d", name, n)
+		}
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
@@ -0,0 +1,283 @@
+// Package mattest provides tolerance based comparisons of numbers and matrices
+// with failure reports for testing code that uses the mat package.
+package mattest
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/number"
+)
+
+// Tolerance decides when two numbers are close enough to be considered equal.
+// Two numbers are close if they are equal, or if any of the enabled criteria accepts
+// their difference. Infinities are only close to themselves.
+type Tolerance struct {
+	// Abs is the largest accepted absolute difference |a-b|.
+	Abs float64
+	// Rel is the largest accepted difference relative to the larger magnitude of a and b.
+	Rel float64
+	// ULP is the largest accepted distance in units in the last place. It is ignored for integers.
+	ULP uint64
+	// NaNEqual makes NaN equal to NaN. Otherwise a NaN is never equal to anything.
+	NaNEqual bool
+}
+
+// Default is the tolerance used by EqualMatrix. It accepts a relative difference of
+// 1e-7 and absolute differences of 1e-12 so that values close to zero can be compared.
+var Default = Tolerance{Abs: 1e-12, Rel: 1e-7}
+
+// maxReported is the number of worst mismatches kept in a Report.
+const maxReported = 5
+
+// Close reports whether a and b are equal within tol. Integers are compared exactly, so
+// values above 2^53 that differ are never rounded to the same float64, and integers
+// far apart don't overflow.
+func Close[T number.Num](a, b T, tol Tolerance) bool {
+	if a == b {
+		return true
+	}
+	if !isFloat[T]() {
+		return closeInt(int64(a), int64(b), tol)
+	}
+
+	x, y := float64(a), float64(b)
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return tol.NaNEqual && math.IsNaN(x) && math.IsNaN(y)
+	}
+	if math.IsInf(x, 0) || math.IsInf(y, 0) {
+		return false
+	}
+
+	diff := math.Abs(x - y)
+	if diff <= tol.Abs || diff <= tol.Rel*math.Max(math.Abs(x), math.Abs(y)) {
+		return true
+	}
+
+	return tol.ULP > 0 && isFloat[T]() && ulpDistance(a, b) <= tol.ULP
+}
+
+// closeInt reports whether the integers a and b are within the absolute or relative
+// tolerance of tol. Differences and magnitudes are taken in uint64, where they are exact.
+func closeInt(a, b int64, tol Tolerance) bool {
+	if a < b {
+		a, b = b, a
+	}
+	diff := uint64(a) - uint64(b)
+	return atMost(diff, tol.Abs) || atMost(diff, tol.Rel*float64(max(absUint(a), absUint(b))))
+}
+
+// atMost reports whether n <= bound, without rounding n to a float64.
+func atMost(n uint64, bound float64) bool {
+	switch {
+	case !(bound >= 0):
+		return false
+	case bound >= 1<<64:
+		return true
+	default:
+		return n <= uint64(bound)
+	}
+}
+
+func absUint(x int64) uint64 {
+	if x < 0 {
+		return uint64(-(x + 1)) + 1
+	}
+	return uint64(x)
+}
+
+// ULPDistance returns the number of representable floats between a and b, so that
+// adjacent floats have distance 1. Zeros of either sign have distance 0, and NaN
+// has the largest possible distance to everything.
+func ULPDistance[T number.Float](a, b T) uint64 {
+	return ulpDistance(a, b)
+}
+
+func ulpDistance[T number.Num](a, b T) uint64 {
+	x, y := float64(a), float64(b)
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return math.MaxUint64
+	}
+
+	var oa, ob int64
+	if _, ok := any(a).(float32); ok {
+		oa, ob = ordered32(float32(a)), ordered32(float32(b))
+	} else {
+		oa, ob = ordered64(x), ordered64(y)
+	}
+
+	if oa < ob {
+		oa, ob = ob, oa
+	}
+	return uint64(oa) - uint64(ob)
+}
+
+// ordered64 maps the bits of f to integers that are ordered like the floats,
+// with both zeros mapping to 0.
+func ordered64(f float64) int64 {
+	b := int64(math.Float64bits(f))
+	if b < 0 {
+		b = math.MinInt64 - b
+	}
+	return b
+}
+
+func ordered32(f float32) int64 {
+	b := int32(math.Float32bits(f))
+	if b < 0 {
+		b = math.MinInt32 - b
+	}
+	return int64(b)
+}
+
+func isFloat[T number.Num]() bool {
+	switch any(*new(T)).(type) {
+	case float32, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// Mismatch describes an element that is not within the tolerance.
+type Mismatch struct {
+	// Row and Col are the 1-based indices of the element, as used by Mat.At.
+	Row, Col int
+	Got      float64
+	Want     float64
+	// AbsDiff is |Got-Want| and RelDiff is AbsDiff relative to the larger magnitude.
+	AbsDiff float64
+	RelDiff float64
+}
+
+func (m Mismatch) String() string {
+	return fmt.Sprintf("At(%d, %d): got %v, want %v (abs diff %.3g, rel diff %.3g)", m.Row, m.Col, m.Got, m.Want, m.AbsDiff, m.RelDiff)
+}
+
+// Report describes how two matrices differ.
+type Report struct {
+	GotRows, GotCols   int
+	WantRows, WantCols int
+	// Count is the number of elements that are not within the tolerance.
+	Count int
+	// Worst holds up to five mismatches with the largest absolute difference, worst first.
+	// NaN differences count as the largest.
+	Worst     []Mismatch
+	Tolerance Tolerance
+}
+
+// ShapeMismatch reports whether the matrices have different dimensions.
+func (r *Report) ShapeMismatch() bool {
+	return r.GotRows != r.WantRows || r.GotCols != r.WantCols
+}
+
+func (r *Report) String() string {
+	if r.ShapeMismatch() {
+		return fmt.Sprintf("matrix dimensions differ: got %dx%d, want %dx%d", r.GotRows, r.GotCols, r.WantRows, r.WantCols)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "matrices differ in %d of %d elements (abs tolerance %g, rel tolerance %g", r.Count, r.GotRows*r.GotCols, r.Tolerance.Abs, r.Tolerance.Rel)
+	if r.Tolerance.ULP > 0 {
+		fmt.Fprintf(&sb, ", %d ULP", r.Tolerance.ULP)
+	}
+	sb.WriteString("), worst:")
+	for _, m := range r.Worst {
+		sb.WriteString("\n\t")
+		sb.WriteString(m.String())
+	}
+	return sb.String()
+}
+
+// Compare compares got against want element by element and returns nil if all the
+// elements are within tol, or a report of the differences otherwise. Matrices that
+// aren't a Mat, such as a lazy transpose, are compared through a dense copy.
+func Compare[T number.Num](a, b mat.Matrix[T], tol Tolerance) *Report {
+	got, want := mat.DenseOf(a), mat.DenseOf(b)
+	r := &Report{
+		GotRows:   got.M,
+		GotCols:   got.N,
+		WantRows:  want.M,
+		WantCols:  want.N,
+		Tolerance: tol,
+	}
+	if r.ShapeMismatch() {
+		return r
+	}
+
+	for i := range want.Data {
+		g, w := got.Data[i], want.Data[i]
+		if Close(g, w, tol) {
+			continue
+		}
+		r.Count++
+
+		x, y := float64(g), float64(w)
+		diff := math.Abs(x - y)
+		m := Mismatch{
+			Row:     i/want.N + 1,
+			Col:     i%want.N + 1,
+			Got:     x,
+			Want:    y,
+			AbsDiff: diff,
+			RelDiff: diff / math.Max(math.Abs(x), math.Abs(y)),
+		}
+		r.Worst = insertWorst(r.Worst, m)
+	}
+
+	if r.Count == 0 {
+		return nil
+	}
+	return r
+}
+
+// insertWorst inserts m into worst, which is sorted by decreasing difference,
+// keeping at most maxReported entries.
+func insertWorst(worst []Mismatch, m Mismatch) []Mismatch {
+	pos := len(worst)
+	for pos > 0 && worse(m, worst[pos-1]) {
+		pos--
+	}
+	if pos >= maxReported {
+		return worst
+	}
+
+	if len(worst) < maxReported {
+		worst = append(worst, Mismatch{})
+	}
+	copy(worst[pos+1:], worst[pos:])
+	worst[pos] = m
+	return worst
+}
+
+func worse(a, b Mismatch) bool {
+	if math.IsNaN(b.AbsDiff) {
+		return false
+	}
+	return math.IsNaN(a.AbsDiff) || a.AbsDiff > b.AbsDiff
+}
+
+// EqualMatrix reports whether m1 and m2 have the same dimensions and their elements
+// are equal within the Default tolerance.
+func EqualMatrix[T number.Num](m1, m2 mat.Matrix[T]) bool {
+	return Compare(m1, m2, Default) == nil
+}
+
+// EqualMatrixTol is like EqualMatrix with a custom tolerance.
+func EqualMatrixTol[T number.Num](m1, m2 mat.Matrix[T], tol Tolerance) bool {
+	return Compare(m1, m2, tol) == nil
+}
+
+// AssertEqual reports a test error with the differences if got and want are not
+// equal within tol, and returns whether they were equal.
+func AssertEqual[T number.Num](tb testing.TB, got, want mat.Matrix[T], tol Tolerance) bool {
+	tb.Helper()
+	if r := Compare(got, want, tol); r != nil {
+		tb.Errorf("%s", r)
+		return false
+	}
+	return true
+}
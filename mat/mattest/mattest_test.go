@@ -0,0 +1,155 @@
+package mattest_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+func TestClose(t *testing.T) {
+	start := time.Now()
+
+	nan := math.NaN()
+	inf := math.Inf(1)
+
+	testCases := []struct {
+		name     string
+		a, b     float64
+		tol      mattest.Tolerance
+		expected bool
+	}{
+		{"equal", 1.5, 1.5, mattest.Tolerance{}, true},
+		{"zero and tiny", 0, 1e-15, mattest.Default, true},
+		{"zero and small", 0, 1e-9, mattest.Default, false},
+		{"relative", 1e9, 1e9 + 50, mattest.Default, true},
+		{"opposite signs", 1, -1, mattest.Tolerance{Rel: 0.5}, false},
+		{"infinity", inf, inf, mattest.Tolerance{}, true},
+		{"infinity and large", inf, math.MaxFloat64, mattest.Tolerance{Rel: 1}, false},
+		{"NaN", nan, nan, mattest.Default, false},
+		{"NaN equal", nan, nan, mattest.Tolerance{NaNEqual: true}, true},
+		{"NaN and number", nan, 1, mattest.Tolerance{NaNEqual: true}, false},
+		{"ULP", 1, math.Nextafter(math.Nextafter(1, 2), 2), mattest.Tolerance{ULP: 2}, true},
+		{"ULP too far", 1, 1 + 1e-9, mattest.Tolerance{ULP: 2}, false},
+	}
+
+	for _, tc := range testCases {
+		if result := mattest.Close(tc.a, tc.b, tc.tol); result != tc.expected {
+			t.Errorf("Wrong result for %s: Close(%g, %g). Want: %v, Got: %v", tc.name, tc.a, tc.b, tc.expected, result)
+		}
+	}
+
+	// Integers far apart don't overflow
+	if mattest.Close[int64](math.MaxInt64, math.MinInt64, mattest.Default) {
+		t.Errorf("Expected MaxInt64 and MinInt64 not to be close")
+	}
+	if !mattest.Close[int8](100, 101, mattest.Tolerance{Abs: 1}) {
+		t.Errorf("Expected 100 and 101 to be close with an absolute tolerance of 1")
+	}
+
+	// Integers above 2^53 are compared exactly, not after rounding to float64
+	big := int64(1<<53 + 1)
+	if mattest.Close[int64](big, big+1, mattest.Tolerance{}) {
+		t.Errorf("Expected %d and %d not to be equal with a zero tolerance", big, big+1)
+	}
+	if !mattest.Close[int64](big, big+2, mattest.Tolerance{Abs: 2}) || mattest.Close[int64](big, big+3, mattest.Tolerance{Abs: 2.9}) {
+		t.Errorf("Wrong absolute tolerance for integers above 2^53")
+	}
+	if !mattest.Close[int64](math.MinInt64, math.MinInt64+1, mattest.Tolerance{Rel: 1e-18}) {
+		t.Errorf("Expected MinInt64 and MinInt64+1 to be close with a relative tolerance of 1e-18")
+	}
+	a, _ := mat.New([][]int64{{big}})
+	b, _ := mat.New([][]int64{{big + 1}})
+	if mattest.EqualMatrixTol(a, b, mattest.Tolerance{}) {
+		t.Errorf("Expected matrices differing by 1 above 2^53 not to be equal")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestULPDistance(t *testing.T) {
+	start := time.Now()
+
+	testCases := []struct {
+		a, b     float64
+		expected uint64
+	}{
+		{1, 1, 0},
+		{0, math.Copysign(0, -1), 0},
+		{1, math.Nextafter(1, 2), 1},
+		{math.SmallestNonzeroFloat64, -math.SmallestNonzeroFloat64, 2},
+		{1, math.NaN(), math.MaxUint64},
+	}
+
+	for _, tc := range testCases {
+		if result := mattest.ULPDistance(tc.a, tc.b); result != tc.expected {
+			t.Errorf("Wrong ULP distance between %g and %g. Want: %d, Got: %d", tc.a, tc.b, tc.expected, result)
+		}
+	}
+
+	if result := mattest.ULPDistance(float32(1), math.Nextafter32(1, 0)); result != 1 {
+		t.Errorf("Wrong float32 ULP distance. Want: 1, Got: %d", result)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestCompare(t *testing.T) {
+	start := time.Now()
+
+	want, _ := mat.New([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	got, _ := mat.New([][]float64{
+		{1, 2.5, 3},
+		{4, math.NaN(), 6.1},
+	})
+
+	// Test case 1: Equal matrices
+	if r := mattest.Compare(want, want, mattest.Default); r != nil {
+		t.Errorf("Expected no report for equal matrices, got: %s", r)
+	}
+	if !mattest.EqualMatrix(want, want) {
+		t.Errorf("Expected matrix to equal itself")
+	}
+
+	// Test case 2: Mismatches are reported worst first with 1-based indices
+	r := mattest.Compare(got, want, mattest.Default)
+	if r == nil {
+		t.Fatalf("Expected a report for different matrices, got nil")
+	}
+	if r.Count != 3 || len(r.Worst) != 3 {
+		t.Fatalf("Wrong number of mismatches. Want: 3, Got: %d (%d reported)", r.Count, len(r.Worst))
+	}
+	expected := [][2]int{{2, 2}, {1, 2}, {2, 3}}
+	for i, m := range r.Worst {
+		if m.Row != expected[i][0] || m.Col != expected[i][1] {
+			t.Errorf("Wrong mismatch %d. Want: At(%d, %d), Got: At(%d, %d)", i, expected[i][0], expected[i][1], m.Row, m.Col)
+		}
+	}
+	if s := r.String(); !strings.Contains(s, "3 of 6 elements") || !strings.Contains(s, "At(1, 2): got 2.5, want 2") {
+		t.Errorf("Report is missing details:\n%s", s)
+	}
+
+	// Test case 3: Only the worst mismatches are kept
+	zeros, _ := mat.Zeros[int](10, 10)
+	ones, _ := mat.Ones[int](10, 10)
+	ones.Data[42] = 9
+	r = mattest.Compare(ones, zeros, mattest.Default)
+	if r.Count != 100 || len(r.Worst) != 5 || r.Worst[0].Row != 5 || r.Worst[0].Col != 3 {
+		t.Errorf("Wrong report for many mismatches:\n%s", r)
+	}
+
+	// Test case 4: Dimensions differ
+	r = mattest.Compare(want, mat.Transpose(want), mattest.Default)
+	if r == nil || !r.ShapeMismatch() || r.String() != "matrix dimensions differ: got 2x3, want 3x2" {
+		t.Errorf("Wrong report for different dimensions: %v", r)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
@@ -0,0 +1,176 @@
+package mattest
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/number"
+)
+
+// Random returns an m x n matrix of independent random elements. Floats are uniform
+// in [-1, 1) and integers uniform in [-10, 10], which keeps products of moderately
+// sized integer matrices from overflowing.
+func Random[T number.Num](rng *rand.Rand, m, n int) *mat.Mat[T] {
+	checkDims(m, n)
+
+	data := make([]T, m*n)
+	for i := range data {
+		if isFloat[T]() {
+			data[i] = T(2*rng.Float64() - 1)
+		} else {
+			data[i] = T(rng.Intn(21) - 10)
+		}
+	}
+
+	return &mat.Mat[T]{M: m, N: n, Data: data}
+}
+
+// RandomOrthogonal returns a random n x n orthogonal matrix Q with QᵀQ = I, drawn
+// uniformly from the orthogonal group.
+func RandomOrthogonal[T number.Float](rng *rand.Rand, n int) *mat.Mat[T] {
+	checkDims(n, n)
+	return fromFloat64[T](n, n, orthogonal(rng, n))
+}
+
+// RandomTriangular returns a random n x n upper or lower triangular matrix. The
+// diagonal elements are at least 1 in magnitude and the off-diagonal elements at
+// most 1/n, so the matrix is well conditioned.
+func RandomTriangular[T number.Float](rng *rand.Rand, n int, upper bool) *mat.Mat[T] {
+	checkDims(n, n)
+
+	data := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			switch {
+			case i == j:
+				d := 1 + rng.Float64()
+				if rng.Intn(2) == 0 {
+					d = -d
+				}
+				data[i*n+j] = d
+			case (j > i) == upper:
+				data[i*n+j] = (2*rng.Float64() - 1) / float64(n)
+			}
+		}
+	}
+
+	return fromFloat64[T](n, n, data)
+}
+
+// RandomSPD returns a random n x n symmetric positive definite matrix with condition
+// number cond. Its eigenvalues are spaced logarithmically between 1/cond and 1.
+func RandomSPD[T number.Float](rng *rand.Rand, n int, cond float64) *mat.Mat[T] {
+	checkDims(n, n)
+	checkCond(cond)
+
+	q := orthogonal(rng, n)
+	eig := spectrum(n, cond)
+
+	// Q diag(eig) Qᵀ
+	data := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			var s float64
+			for k := 0; k < n; k++ {
+				s += q[i*n+k] * eig[k] * q[j*n+k]
+			}
+			data[i*n+j] = s
+			data[j*n+i] = s
+		}
+	}
+
+	return fromFloat64[T](n, n, data)
+}
+
+// RandomConditioned returns a random m x n matrix with the 2-norm condition number
+// cond. Its singular values are spaced logarithmically between 1/cond and 1.
+func RandomConditioned[T number.Float](rng *rand.Rand, m, n int, cond float64) *mat.Mat[T] {
+	checkDims(m, n)
+	checkCond(cond)
+
+	u := orthogonal(rng, m)
+	v := orthogonal(rng, n)
+	sigma := spectrum(min(m, n), cond)
+
+	// U Σ Vᵀ using the first min(m, n) columns of U and V
+	data := make([]float64, m*n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			var s float64
+			for k, sk := range sigma {
+				s += u[i*m+k] * sk * v[j*n+k]
+			}
+			data[i*n+j] = s
+		}
+	}
+
+	return fromFloat64[T](m, n, data)
+}
+
+// orthogonal returns a row-major n x n orthogonal matrix. It orthonormalizes the
+// columns of a Gaussian matrix with modified Gram-Schmidt, which yields a uniformly
+// distributed Q because the implied R factor has a positive diagonal.
+func orthogonal(rng *rand.Rand, n int) []float64 {
+	q := make([]float64, n*n)
+	for i := range q {
+		q[i] = rng.NormFloat64()
+	}
+
+	for j := 0; j < n; j++ {
+		for k := 0; k < j; k++ {
+			var dot float64
+			for i := 0; i < n; i++ {
+				dot += q[i*n+k] * q[i*n+j]
+			}
+			for i := 0; i < n; i++ {
+				q[i*n+j] -= dot * q[i*n+k]
+			}
+		}
+
+		var norm float64
+		for i := 0; i < n; i++ {
+			norm += q[i*n+j] * q[i*n+j]
+		}
+		norm = math.Sqrt(norm)
+		for i := 0; i < n; i++ {
+			q[i*n+j] /= norm
+		}
+	}
+
+	return q
+}
+
+// spectrum returns k values spaced logarithmically from 1 down to 1/cond.
+func spectrum(k int, cond float64) []float64 {
+	s := make([]float64, k)
+	for i := range s {
+		if k == 1 {
+			s[i] = 1
+			continue
+		}
+		s[i] = math.Pow(cond, -float64(i)/float64(k-1))
+	}
+	return s
+}
+
+func fromFloat64[T number.Num](m, n int, data []float64) *mat.Mat[T] {
+	res := make([]T, len(data))
+	for i, v := range data {
+		res[i] = T(v)
+	}
+	return &mat.Mat[T]{M: m, N: n, Data: res}
+}
+
+func checkDims(m, n int) {
+	if m <= 0 || n <= 0 {
+		panic(fmt.Sprintf("mattest: dimensions of matrices must be above zero, got %dx%d", m, n))
+	}
+}
+
+func checkCond(cond float64) {
+	if !(cond >= 1) || math.IsInf(cond, 1) {
+		panic(fmt.Sprintf("mattest: condition number must be finite and at least 1, got %g", cond))
+	}
+}
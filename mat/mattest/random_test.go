@@ -0,0 +1,108 @@
+package mattest_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+func TestRandomOrthogonal(t *testing.T) {
+	start := time.Now()
+
+	rng := rand.New(rand.NewSource(1))
+	q := mattest.RandomOrthogonal[float64](rng, 20)
+
+	qtq, _ := mat.Dot(mat.Transpose(q), q)
+	mattest.AssertEqual(t, qtq, identity(20), mattest.Tolerance{Abs: 1e-12})
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestRandomSPD(t *testing.T) {
+	start := time.Now()
+
+	rng := rand.New(rand.NewSource(2))
+	a := mattest.RandomSPD[float64](rng, 15, 100)
+
+	mattest.AssertEqual(t, a, mat.Transpose(a), mattest.Tolerance{})
+
+	// xᵀAx is positive for any non-zero x
+	for range 20 {
+		x := mattest.Random[float64](rng, 15, 1)
+		ax, _ := mat.Dot(a, x)
+		if xax := mat.VectorDot(x, ax); xax <= 0 {
+			t.Errorf("Expected xᵀAx > 0 for an SPD matrix, got %g", xax)
+		}
+	}
+
+	// The eigenvalues run from 1/cond to 1, so the trace is their sum
+	var trace, expected float64
+	for i := 1; i <= 15; i++ {
+		trace += a.At(i, i)
+		expected += math.Pow(100, -float64(i-1)/14)
+	}
+	if math.Abs(trace-expected) > 1e-12 {
+		t.Errorf("Wrong trace of SPD matrix. Want: %g, Got: %g", expected, trace)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestRandomConditioned(t *testing.T) {
+	start := time.Now()
+
+	rng := rand.New(rand.NewSource(3))
+	a := mattest.RandomConditioned[float64](rng, 12, 7, 1e6)
+	if a.M != 12 || a.N != 7 {
+		t.Fatalf("Wrong dimensions. Want: 12x7, Got: %dx%d", a.M, a.N)
+	}
+
+	// The squared Frobenius norm is the sum of the squared singular values
+	var norm, expected float64
+	for _, v := range a.Data {
+		norm += v * v
+	}
+	for i := range 7 {
+		s := math.Pow(1e6, -float64(i)/6)
+		expected += s * s
+	}
+	if math.Abs(norm-expected) > 1e-12 {
+		t.Errorf("Wrong Frobenius norm. Want: %g, Got: %g", expected, norm)
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestRandomTriangular(t *testing.T) {
+	start := time.Now()
+
+	rng := rand.New(rand.NewSource(4))
+	for _, upper := range []bool{true, false} {
+		a := mattest.RandomTriangular[float32](rng, 8, upper)
+		for i := 1; i <= 8; i++ {
+			if math.Abs(float64(a.At(i, i))) < 1 {
+				t.Errorf("Diagonal element %d is smaller than 1: %g", i, a.At(i, i))
+			}
+			for j := 1; j <= 8; j++ {
+				if (upper && j < i || !upper && j > i) && a.At(i, j) != 0 {
+					t.Errorf("Expected zero at (%d, %d) of triangular matrix (upper=%v), got %g", i, j, upper, a.At(i, j))
+				}
+			}
+		}
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func identity(n int) *mat.Mat[float64] {
+	m, _ := mat.Zeros[float64](n, n)
+	for i := 0; i < n; i++ {
+		m.Data[i*n+i] = 1
+	}
+	return m
+}
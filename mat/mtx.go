@@ -0,0 +1,403 @@
+package mat
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lattots/gonum/number"
+)
+
+// MatrixMarketFormat is the storage layout of a Matrix Market file.
+type MatrixMarketFormat int
+
+const (
+	// MatrixMarketCoordinate lists only the non-zero entries as "row column value" triplets.
+	MatrixMarketCoordinate MatrixMarketFormat = iota
+	// MatrixMarketArray lists every entry in column-major order.
+	MatrixMarketArray
+)
+
+// MatrixMarketSymmetry is the symmetry structure declared in a Matrix Market header.
+type MatrixMarketSymmetry int
+
+const (
+	// MatrixMarketGeneral stores every entry.
+	MatrixMarketGeneral MatrixMarketSymmetry = iota
+	// MatrixMarketSymmetric stores only the lower triangle, with a[i][j] == a[j][i].
+	MatrixMarketSymmetric
+	// MatrixMarketSkewSymmetric stores only the strict lower triangle, with a[i][j] == -a[j][i].
+	MatrixMarketSkewSymmetric
+)
+
+// MatrixMarketOptions controls how WriteMatrixMarket lays out a matrix.
+type MatrixMarketOptions struct {
+	Format   MatrixMarketFormat
+	Symmetry MatrixMarketSymmetry
+	// Comment is written after the header, one "%" comment line per line of text.
+	Comment string
+}
+
+const matrixMarketBanner = "%%MatrixMarket"
+
+type matrixMarketHeader struct {
+	format   MatrixMarketFormat
+	pattern  bool
+	symmetry MatrixMarketSymmetry
+}
+
+// maxMatrixMarketDense is the largest number of elements ReadMatrixMarket expands a
+// file into, 2^28 or 2 GiB of float64. Larger matrices are read with ReadMatrixMarketCSR.
+const maxMatrixMarketDense = 1 << 28
+
+// ReadMatrixMarket decodes a matrix in the Matrix Market exchange format into a dense matrix.
+// Both coordinate and array layouts are supported with real, integer or pattern fields and
+// general, symmetric or skew-symmetric structure. Pattern entries are read as 1, and
+// repeated coordinate entries are summed. Complex and Hermitian matrices are not supported.
+// Matrices with more than 2^28 elements are rejected instead of being expanded, as is
+// typical of large sparse collections such as SuiteSparse; use ReadMatrixMarketCSR for
+// those. Malformed input is reported as a *ParseError.
+func ReadMatrixMarket[T number.Num](r io.Reader) (*Mat[T], error) {
+	var result *Mat[T]
+	err := readMatrixMarket(r, func(rows, cols int) error {
+		if rows > maxMatrixMarketDense/cols {
+			return fmt.Errorf("matrix dimensions %dx%d are too large for a dense matrix, use ReadMatrixMarketCSR", rows, cols)
+		}
+		result, _ = Zeros[T](rows, cols)
+		return nil
+	}, func(i, j int, v T) {
+		result.Data[i*result.N+j] += v
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReadMatrixMarketCSR decodes a matrix in the Matrix Market exchange format into a sparse
+// matrix, which takes memory in proportion to the number of entries instead of the
+// dimensions. It accepts the same files as ReadMatrixMarket. Symmetric entries are stored
+// in both triangles, repeated entries are summed, and zero entries are left out.
+func ReadMatrixMarketCSR[T number.Num](r io.Reader) (*CSR[T], error) {
+	var b csrBuilder[T]
+	err := readMatrixMarket(r, func(rows, cols int) error {
+		b.m, b.n = rows, cols
+		return nil
+	}, b.add)
+	if err != nil {
+		return nil, err
+	}
+	return b.build(), nil
+}
+
+// readMatrixMarket parses a Matrix Market file. It calls size with the dimensions before
+// any entry, and add with every zero-based entry, mirrored across the diagonal for
+// symmetric storage. An error from size is reported as a *ParseError on the size line.
+func readMatrixMarket[T number.Num](r io.Reader, size func(rows, cols int) error, add func(i, j int, v T)) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+
+	// nextLine returns the fields of the next line that isn't blank or a comment.
+	nextLine := func() ([]string, error) {
+		for sc.Scan() {
+			line++
+			text := strings.TrimSpace(sc.Text())
+			if text == "" || strings.HasPrefix(text, "%") {
+				continue
+			}
+			return strings.Fields(text), nil
+		}
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return err
+		}
+		return &ParseError{Line: 1, Err: io.ErrUnexpectedEOF}
+	}
+	line++
+	header, err := parseMatrixMarketHeader(sc.Text())
+	if err != nil {
+		return &ParseError{Line: line, Err: err}
+	}
+
+	fields, err := nextLine()
+	if err != nil {
+		return &ParseError{Line: line, Err: err}
+	}
+	wantFields := 3
+	if header.format == MatrixMarketArray {
+		wantFields = 2
+	}
+	if len(fields) != wantFields {
+		return &ParseError{Line: line, Err: fmt.Errorf("size line must have %d fields, got %d", wantFields, len(fields))}
+	}
+	dims := make([]int, len(fields))
+	for i, f := range fields {
+		dims[i], err = strconv.Atoi(f)
+		if err != nil || dims[i] < 0 {
+			return &ParseError{Line: line, Column: i + 1, Err: fmt.Errorf("invalid size %q", f)}
+		}
+	}
+
+	rows, cols := dims[0], dims[1]
+	if rows == 0 || cols == 0 {
+		return &ParseError{Line: line, Err: fmt.Errorf("dimensions of matrices must be above zero")}
+	}
+	if rows > math.MaxInt/cols {
+		return &ParseError{Line: line, Err: fmt.Errorf("matrix dimensions %dx%d are too large", rows, cols)}
+	}
+	if header.symmetry != MatrixMarketGeneral && rows != cols {
+		return &ParseError{Line: line, Err: fmt.Errorf("symmetric matrix must be square, got %dx%d", rows, cols)}
+	}
+	if err := size(rows, cols); err != nil {
+		return &ParseError{Line: line, Err: err}
+	}
+
+	// set passes on a zero-based entry and mirrors it across the diagonal for symmetric storage.
+	set := func(i, j int, v T) {
+		add(i, j, v)
+		if i == j {
+			return
+		}
+		switch header.symmetry {
+		case MatrixMarketSymmetric:
+			add(j, i, v)
+		case MatrixMarketSkewSymmetric:
+			add(j, i, -v)
+		}
+	}
+
+	if header.format == MatrixMarketCoordinate {
+		entries := dims[2]
+		wantFields := 3
+		if header.pattern {
+			wantFields = 2
+		}
+
+		for e := 0; e < entries; e++ {
+			fields, err := nextLine()
+			if err != nil {
+				return &ParseError{Line: line, Err: fmt.Errorf("expected %d entries, got %d: %w", entries, e, err)}
+			}
+			if len(fields) != wantFields {
+				return &ParseError{Line: line, Err: fmt.Errorf("entry must have %d fields, got %d", wantFields, len(fields))}
+			}
+
+			i, err := strconv.Atoi(fields[0])
+			if err != nil || i < 1 || i > rows {
+				return &ParseError{Line: line, Column: 1, Err: fmt.Errorf("row index %q out of range 1..%d", fields[0], rows)}
+			}
+			j, err := strconv.Atoi(fields[1])
+			if err != nil || j < 1 || j > cols {
+				return &ParseError{Line: line, Column: 2, Err: fmt.Errorf("column index %q out of range 1..%d", fields[1], cols)}
+			}
+			if header.symmetry == MatrixMarketSkewSymmetric && i == j {
+				return &ParseError{Line: line, Err: fmt.Errorf("skew-symmetric matrix can't store diagonal entries")}
+			}
+
+			v := T(1)
+			if !header.pattern {
+				v, err = parseNum[T](fields[2])
+				if err != nil {
+					return &ParseError{Line: line, Column: 3, Err: err}
+				}
+			}
+			set(i-1, j-1, v)
+		}
+	} else {
+		// Array entries run down the columns, only over the stored triangle for symmetric matrices.
+		for j := 0; j < cols; j++ {
+			first := 0
+			switch header.symmetry {
+			case MatrixMarketSymmetric:
+				first = j
+			case MatrixMarketSkewSymmetric:
+				first = j + 1
+			}
+
+			for i := first; i < rows; i++ {
+				fields, err := nextLine()
+				if err != nil {
+					return &ParseError{Line: line, Err: fmt.Errorf("missing entry (%d, %d): %w", i+1, j+1, err)}
+				}
+				if len(fields) != 1 {
+					return &ParseError{Line: line, Err: fmt.Errorf("array entry must have 1 field, got %d", len(fields))}
+				}
+				v, err := parseNum[T](fields[0])
+				if err != nil {
+					return &ParseError{Line: line, Column: 1, Err: err}
+				}
+				set(i, j, v)
+			}
+		}
+	}
+
+	if fields, err := nextLine(); err == nil {
+		return &ParseError{Line: line, Err: fmt.Errorf("unexpected data after the last entry: %q", strings.Join(fields, " "))}
+	} else if !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+
+	return nil
+}
+
+func parseMatrixMarketHeader(text string) (matrixMarketHeader, error) {
+	var header matrixMarketHeader
+
+	fields := strings.Fields(text)
+	if len(fields) != 5 || !strings.EqualFold(fields[0], matrixMarketBanner) {
+		return header, fmt.Errorf("missing %s header", matrixMarketBanner)
+	}
+	if !strings.EqualFold(fields[1], "matrix") {
+		return header, fmt.Errorf("unsupported object %q", fields[1])
+	}
+
+	switch strings.ToLower(fields[2]) {
+	case "coordinate":
+		header.format = MatrixMarketCoordinate
+	case "array":
+		header.format = MatrixMarketArray
+	default:
+		return header, fmt.Errorf("unsupported format %q", fields[2])
+	}
+
+	switch strings.ToLower(fields[3]) {
+	case "real", "double", "integer":
+	case "pattern":
+		if header.format == MatrixMarketArray {
+			return header, fmt.Errorf("pattern field requires coordinate format")
+		}
+		header.pattern = true
+	default:
+		return header, fmt.Errorf("unsupported field %q", fields[3])
+	}
+
+	switch strings.ToLower(fields[4]) {
+	case "general":
+		header.symmetry = MatrixMarketGeneral
+	case "symmetric":
+		header.symmetry = MatrixMarketSymmetric
+	case "skew-symmetric":
+		header.symmetry = MatrixMarketSkewSymmetric
+	default:
+		return header, fmt.Errorf("unsupported symmetry %q", fields[4])
+	}
+
+	return header, nil
+}
+
+// WriteMatrixMarket encodes m in the Matrix Market exchange format. Integer matrices are
+// written with the integer field and float matrices with the real field, using the fewest
+// digits that read back exactly. The coordinate format only lists non-zero entries.
+// For symmetric and skew-symmetric output m must have that structure, and only its lower
+// triangle is written.
+func WriteMatrixMarket[T number.Num](w io.Writer, m *Mat[T], opts MatrixMarketOptions) error {
+	var format, symmetry string
+	switch opts.Format {
+	case MatrixMarketCoordinate:
+		format = "coordinate"
+	case MatrixMarketArray:
+		format = "array"
+	default:
+		return fmt.Errorf("unsupported Matrix Market format %d", opts.Format)
+	}
+
+	// first is the offset from the diagonal to the first stored row in each column.
+	first := -1
+	switch opts.Symmetry {
+	case MatrixMarketGeneral:
+		symmetry = "general"
+	case MatrixMarketSymmetric:
+		symmetry = "symmetric"
+		first = 0
+	case MatrixMarketSkewSymmetric:
+		symmetry = "skew-symmetric"
+		first = 1
+	default:
+		return fmt.Errorf("unsupported Matrix Market symmetry %d", opts.Symmetry)
+	}
+	if first >= 0 {
+		if err := checkSymmetry(m, opts.Symmetry); err != nil {
+			return err
+		}
+	}
+
+	field := "integer"
+	if isFloat[T]() {
+		field = "real"
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s matrix %s %s %s\n", matrixMarketBanner, format, field, symmetry)
+	if opts.Comment != "" {
+		for _, c := range strings.Split(opts.Comment, "\n") {
+			fmt.Fprintf(bw, "%%%s\n", c)
+		}
+	}
+
+	// stored reports whether entry (i, j) is part of the written triangle.
+	stored := func(i, j int) bool {
+		return first < 0 || i >= j+first
+	}
+
+	if opts.Format == MatrixMarketCoordinate {
+		entries := 0
+		for i := 0; i < m.M; i++ {
+			for j := 0; j < m.N; j++ {
+				if stored(i, j) && m.Data[i*m.N+j] != 0 {
+					entries++
+				}
+			}
+		}
+
+		fmt.Fprintf(bw, "%d %d %d\n", m.M, m.N, entries)
+		for i := 0; i < m.M; i++ {
+			for j := 0; j < m.N; j++ {
+				if v := m.Data[i*m.N+j]; stored(i, j) && v != 0 {
+					fmt.Fprintf(bw, "%d %d %s\n", i+1, j+1, formatNum(v))
+				}
+			}
+		}
+	} else {
+		fmt.Fprintf(bw, "%d %d\n", m.M, m.N)
+		for j := 0; j < m.N; j++ {
+			for i := 0; i < m.M; i++ {
+				if stored(i, j) {
+					fmt.Fprintf(bw, "%s\n", formatNum(m.Data[i*m.N+j]))
+				}
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+func checkSymmetry[T number.Num](m *Mat[T], symmetry MatrixMarketSymmetry) error {
+	if m.M != m.N {
+		return shapeErrorf("WriteMatrixMarket", "symmetric storage requires a square matrix, got %dx%d", m.M, m.N)
+	}
+
+	for i := 0; i < m.M; i++ {
+		for j := 0; j <= i; j++ {
+			a, b := m.Data[i*m.N+j], m.Data[j*m.N+i]
+			if symmetry == MatrixMarketSymmetric && a != b {
+				return fmt.Errorf("matrix is not symmetric at (%d, %d)", i+1, j+1)
+			}
+			if symmetry == MatrixMarketSkewSymmetric && a != -b {
+				return fmt.Errorf("matrix is not skew-symmetric at (%d, %d)", i+1, j+1)
+			}
+		}
+	}
+
+	return nil
+}
@@ -9,22 +9,44 @@ import (
 	"github.com/lattots/gonum/number"
 )
 
+// naiveThreshold is the default Strassen crossover, see StrassenThreshold.
 const naiveThreshold = 128
 
-func Dot[T number.Num](m1, m2 *Mat[T]) (*Mat[T], error) {
+// parallelWork is the number of multiplications below which dotNaive runs on the
+// calling goroutine instead of splitting the rows between workers.
+const parallelWork = 1 << 15
+
+// Dot calculates the matrix product of a and b. Below StrassenThreshold each element is
+// accumulated with the strategy set with SetSummation. Larger products use Strassen's
+// algorithm, which builds the elements from sums of blocks and doesn't follow the
+// strategy; use DotWith to apply a strategy at every size.
+func Dot[T number.Num](a, b Matrix[T]) (*Mat[T], error) {
+	// Diagonal matrices only scale the rows of the other factor
+	if d, ok := a.(*DiagDense[T]); ok {
+		return MulDiag(d, DenseOf(b))
+	}
+	// Transposed operands are multiplied in place
+	_, ta := a.(Transposed[T])
+	_, tb := b.(Transposed[T])
+	if ta || tb {
+		return DotTrans(false, false, a, b)
+	}
+
+	m1, m2 := DenseOf(a), DenseOf(b)
 	if m1.N != m2.M {
 		return nil, fmt.Errorf("cannot multiply matrices: Number of columns in the first matrix (%d) must be equal to the number of rows in the second matrix (%d)", m1.N, m2.M)
 	}
 
 	// If any of the dimensions are smaller than the threshold, there is likely no benefit
 	// to using the strassen dot product algorithm.
-	if min(m1.M, m1.N, m2.M, m2.N) < naiveThreshold {
+	threshold := StrassenThreshold[T]()
+	if min(m1.M, m1.N, m2.M, m2.N) < threshold {
 		return dotNaive(m1, m2), nil
 	}
 
 	sq1, sq2 := Square(m1, m2)
 
-	paddedRes := dotStrassen(sq1, sq2)
+	paddedRes := dotStrassen(sq1, sq2, threshold)
 
 	data := make([]T, m1.M*m2.N)
 
@@ -47,12 +69,11 @@ func Dot[T number.Num](m1, m2 *Mat[T]) (*Mat[T], error) {
 	}, nil
 }
 
-func Mul[T number.Num](m1, m2 *Mat[T]) (*Mat[T], error) {
-	if m1.M != m2.M {
-		return nil, fmt.Errorf("number of rows must be equal in both matrices when performing element wise multiplication. %d != %d", m1.M, m2.M)
-	}
-	if m1.N != m2.N {
-		return nil, fmt.Errorf("number of columns must be equal in both matrices when performing element wise multiplication. %d != %d", m1.N, m2.N)
+// Mul multiplies a and b element-wise, broadcasting compatible shapes (see BroadcastShape).
+func Mul[T number.Num](a, b Matrix[T]) (*Mat[T], error) {
+	m1, m2 := DenseOf(a), DenseOf(b)
+	if m1.M != m2.M || m1.N != m2.N {
+		return broadcast("Mul", m1, m2, func(a, b T) T { return a * b })
 	}
 
 	data := make([]T, len(m1.Data))
@@ -67,17 +88,72 @@ func Mul[T number.Num](m1, m2 *Mat[T]) (*Mat[T], error) {
 	}, nil
 }
 
-// dotNaive calculates the dot product of matrices m1 and m2.
-// It expects the input matrices to have compatible shapes.
+// DotWith calculates the matrix product of a and b like Dot, but accumulates every element
+// with the summation strategy s whatever the size and the setting of SetSummation. It
+// never uses Strassen's algorithm, so large products take longer than with Dot. Lazily
+// transposed operands from T are read in place. Panics if s is not one of the defined
+// strategies.
+func DotWith[T number.Num](a, b Matrix[T], s Summation) (*Mat[T], error) {
+	checkSummation(s)
+	// Diagonal matrices only scale the rows of the other factor
+	if d, ok := a.(*DiagDense[T]); ok {
+		return MulDiag(d, DenseOf(b))
+	}
+
+	m1, m2, transA, transB, err := transOperands(false, false, a, b)
+	if err != nil {
+		return nil, err
+	}
+	return dotNaiveTrans(m1, m2, transA, transB, s), nil
+}
+
+// dotNaive calculates the dot product of matrices m1 and m2, accumulating
+// each element with the current summation strategy. It expects the input matrices to have compatible shapes.
 func dotNaive[T number.Num](m1, m2 *Mat[T]) *Mat[T] {
-	result, _ := Zeros[T](m1.M, m2.N)
+	return dotNaiveTrans(m1, m2, false, false, CurrentSummation())
+}
+
+// dotNaiveTrans is dotNaive for operands that may be transposed, accumulating with
+// strategy s. Element (i, k) of op(m1) and element (k, j) of op(m2) are read with the
+// strides of the stored layout.
+func dotNaiveTrans[T number.Num](m1, m2 *Mat[T], transA, transB bool, s Summation) *Mat[T] {
+	rows, inner := m1.M, m1.N
+	// Row i of op(m1) starts at aOffset*i and steps by aStride
+	aOffset, aStride := m1.N, 1
+	if transA {
+		rows, inner = m1.N, m1.M
+		aOffset, aStride = 1, m1.N
+	}
+	cols := m2.N
+	// Column j of op(m2) starts at bOffset*j and steps by bStride
+	bOffset, bStride := 1, m2.N
+	if transB {
+		cols = m2.M
+		bOffset, bStride = m2.N, 1
+	}
+
+	result, _ := Zeros[T](rows, cols)
 
-	numWorkers := min(runtime.GOMAXPROCS(0), m1.M)
+	rowRange := func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := range cols {
+				result.Data[i*cols+j] = dotStrided(m1.Data, i*aOffset, aStride, m2.Data, j*bOffset, bStride, inner, s)
+			}
+		}
+	}
+
+	// Starting goroutines costs more than multiplying small matrices such as 3x3 or 4x4 transforms
+	if rows*inner*cols < parallelWork {
+		rowRange(0, rows)
+		return result
+	}
+
+	numWorkers := min(runtime.GOMAXPROCS(0), rows)
 
 	var wg sync.WaitGroup
 	wg.Add(numWorkers)
 
-	rowsPerWorker := m1.M / numWorkers
+	rowsPerWorker := rows / numWorkers
 
 	for w := range numWorkers {
 		startRow := w * rowsPerWorker
@@ -85,23 +161,12 @@ func dotNaive[T number.Num](m1, m2 *Mat[T]) *Mat[T] {
 
 		// Last worker handles all remaining rows
 		if w == numWorkers-1 {
-			endRow = m1.M
+			endRow = rows
 		}
 
 		go func(start, end int) {
 			defer wg.Done()
-
-			for i := start; i < end; i++ {
-				for j := 0; j < m2.N; j++ {
-					var sum T
-					for k := 0; k < m1.N; k++ {
-						m1Val := m1.Data[i*m1.N+k]
-						m2Val := m2.Data[k*m2.N+j]
-						sum += m1Val * m2Val
-					}
-					result.Data[i*result.N+j] = sum
-				}
-			}
+			rowRange(start, end)
 		}(startRow, endRow)
 	}
 
@@ -109,9 +174,11 @@ func dotNaive[T number.Num](m1, m2 *Mat[T]) *Mat[T] {
 	return result
 }
 
-func dotStrassen[T number.Num](m1, m2 *Mat[T]) *Mat[T] {
+// dotStrassen multiplies square matrices whose size is a power of two, recursing
+// until the blocks are no larger than threshold.
+func dotStrassen[T number.Num](m1, m2 *Mat[T], threshold int) *Mat[T] {
 	// Base case: fall back to naive standard multiplication
-	if m1.M <= naiveThreshold {
+	if m1.M <= threshold {
 		return dotNaive(m1, m2)
 	}
 
@@ -128,7 +195,7 @@ func dotStrassen[T number.Num](m1, m2 *Mat[T]) *Mat[T] {
 	calculateProduct := func(index int, op1, op2 *Mat[T]) {
 		defer wg.Done()
 		// Recursively call dotStrassen
-		products[index] = dotStrassen(op1, op2)
+		products[index] = dotStrassen(op1, op2, threshold)
 	}
 
 	go calculateProduct(0, a11, Subtract(b12, b22))           // p1
@@ -0,0 +1,248 @@
+package mat
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/lattots/gonum/number"
+)
+
+const npyMagic = "\x93NUMPY"
+
+var (
+	npyDescrRe   = regexp.MustCompile(`'descr'\s*:\s*'([^']*)'`)
+	npyFortranRe = regexp.MustCompile(`'fortran_order'\s*:\s*(True|False)`)
+	npyShapeRe   = regexp.MustCompile(`'shape'\s*:\s*\(([^)]*)\)`)
+)
+
+// npyDescr returns the NumPy dtype string for T, e.g. "<f8" for float64.
+func npyDescr[T number.Num]() string {
+	size := byteSize[T]()
+	if size == 1 {
+		return "|i1"
+	}
+	if isFloat[T]() {
+		return fmt.Sprintf("<f%d", size)
+	}
+	return fmt.Sprintf("<i%d", size)
+}
+
+// ReadNPY decodes a NumPy .npy array into a matrix. The array dtype must match T exactly,
+// e.g. float64 reads '<f8' and '>f8' and int32 reads '<i4' and '>i4'. A 1-D array of length
+// n becomes a 1xn row vector and a 0-D array a 1x1 matrix. Arrays in Fortran order are
+// rearranged into the row-major layout of Mat.
+func ReadNPY[T number.Num](r io.Reader) (*Mat[T], error) {
+	preamble := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, preamble); err != nil {
+		return nil, fmt.Errorf("failed to read npy preamble: %w", err)
+	}
+	if string(preamble[:len(npyMagic)]) != npyMagic {
+		return nil, fmt.Errorf("not a npy file: bad magic string")
+	}
+
+	var headerLen int
+	switch major := preamble[len(npyMagic)]; major {
+	case 1:
+		var size [2]byte
+		if _, err := io.ReadFull(r, size[:]); err != nil {
+			return nil, fmt.Errorf("failed to read npy header length: %w", err)
+		}
+		headerLen = int(binary.LittleEndian.Uint16(size[:]))
+	case 2, 3:
+		var size [4]byte
+		if _, err := io.ReadFull(r, size[:]); err != nil {
+			return nil, fmt.Errorf("failed to read npy header length: %w", err)
+		}
+		headerLen = int(binary.LittleEndian.Uint32(size[:]))
+	default:
+		return nil, fmt.Errorf("unsupported npy format version %d", major)
+	}
+
+	header, err := readExactly(r, int64(headerLen))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read npy header: %w", err)
+	}
+
+	order, fortran, shape, err := parseNPYHeader[T](string(header))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, cols := 1, 1
+	switch len(shape) {
+	case 0:
+	case 1:
+		cols = shape[0]
+	case 2:
+		rows, cols = shape[0], shape[1]
+	default:
+		return nil, fmt.Errorf("cannot read %d-dimensional npy array into a matrix", len(shape))
+	}
+	if rows <= 0 || cols <= 0 {
+		return nil, fmt.Errorf("dimensions of matrices must be above zero")
+	}
+
+	if rows > math.MaxInt/cols/byteSize[T]() {
+		return nil, fmt.Errorf("npy array dimensions %dx%d are too large", rows, cols)
+	}
+	raw, err := readExactly(r, int64(rows*cols*byteSize[T]()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read npy data: %w", err)
+	}
+
+	data := make([]T, rows*cols)
+	decodeNums(raw, order, data)
+
+	m := &Mat[T]{
+		M:    rows,
+		N:    cols,
+		Data: data,
+	}
+	if fortran && rows > 1 && cols > 1 {
+		// Column-major data of an MxN array is the row-major data of its NxM transpose.
+		m = Transpose(&Mat[T]{M: cols, N: rows, Data: data})
+	}
+
+	return m, nil
+}
+
+func parseNPYHeader[T number.Num](header string) (binary.ByteOrder, bool, []int, error) {
+	descr := npyDescrRe.FindStringSubmatch(header)
+	fortran := npyFortranRe.FindStringSubmatch(header)
+	shape := npyShapeRe.FindStringSubmatch(header)
+	if descr == nil || fortran == nil || shape == nil {
+		return nil, false, nil, fmt.Errorf("malformed npy header %q", strings.TrimSpace(header))
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	dtype := descr[1]
+	if len(dtype) < 2 {
+		return nil, false, nil, fmt.Errorf("unsupported npy dtype %q", dtype)
+	}
+	switch dtype[0] {
+	case '>':
+		order = binary.BigEndian
+	case '<', '|', '=':
+	default:
+		return nil, false, nil, fmt.Errorf("unsupported npy dtype %q", dtype)
+	}
+	if want := npyDescr[T](); dtype[1:] != want[1:] {
+		return nil, false, nil, fmt.Errorf("npy dtype %q does not match matrix element type %T (%q)", dtype, *new(T), want)
+	}
+
+	var dims []int
+	for _, d := range strings.Split(shape[1], ",") {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		n, err := strconv.Atoi(d)
+		if err != nil {
+			return nil, false, nil, fmt.Errorf("malformed npy shape %q", shape[1])
+		}
+		dims = append(dims, n)
+	}
+
+	return order, fortran[1] == "True", dims, nil
+}
+
+// WriteNPY encodes m as a version 1.0 NumPy .npy array in C order with a little-endian
+// dtype matching T. int is written as a 64-bit or 32-bit integer depending on the platform.
+func WriteNPY[T number.Num](w io.Writer, m *Mat[T]) error {
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%d, %d), }", npyDescr[T](), m.M, m.N)
+
+	// The header is padded with spaces and a newline so that the data starts at a multiple of 64 bytes.
+	prefix := len(npyMagic) + 4
+	padding := 64 - (prefix+len(header)+1)%64
+	if padding == 64 {
+		padding = 0
+	}
+	header += strings.Repeat(" ", padding) + "\n"
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(npyMagic)
+	bw.Write([]byte{1, 0})
+	binary.Write(bw, binary.LittleEndian, uint16(len(header)))
+	bw.WriteString(header)
+
+	raw := make([]byte, len(m.Data)*byteSize[T]())
+	encodeNums(raw, binary.LittleEndian, m.Data)
+	bw.Write(raw)
+
+	return bw.Flush()
+}
+
+// ReadNPZ decodes every array in a NumPy .npz archive, as written by numpy.savez and
+// numpy.savez_compressed. The map is keyed by array name without the ".npy" suffix.
+// All arrays must have a dtype matching T.
+func ReadNPZ[T number.Num](r io.ReaderAt, size int64) (map[string]*Mat[T], error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open npz archive: %w", err)
+	}
+
+	arrays := make(map[string]*Mat[T], len(zr.File))
+	for _, f := range zr.File {
+		name, ok := strings.CutSuffix(f.Name, ".npy")
+		if !ok {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s in npz archive: %w", f.Name, err)
+		}
+		m, err := ReadNPY[T](rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s in npz archive: %w", f.Name, err)
+		}
+
+		arrays[name] = m
+	}
+
+	return arrays, nil
+}
+
+// WriteNPZ encodes the named matrices as a NumPy .npz archive that numpy.load can open.
+// With compress set the entries are deflated like numpy.savez_compressed, otherwise they
+// are stored like numpy.savez.
+func WriteNPZ[T number.Num](w io.Writer, arrays map[string]*Mat[T], compress bool) error {
+	method := zip.Store
+	if compress {
+		method = zip.Deflate
+	}
+
+	names := make([]string, 0, len(arrays))
+	for name := range arrays {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		var buf bytes.Buffer
+		if err := WriteNPY(&buf, arrays[name]); err != nil {
+			return err
+		}
+
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: name + ".npy", Method: method})
+		if err != nil {
+			return fmt.Errorf("failed to add %s to npz archive: %w", name, err)
+		}
+		if _, err := fw.Write(buf.Bytes()); err != nil {
+			return fmt.Errorf("failed to write %s to npz archive: %w", name, err)
+		}
+	}
+
+	return zw.Close()
+}
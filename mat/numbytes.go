@@ -0,0 +1,92 @@
+package mat
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/lattots/gonum/number"
+)
+
+// byteSize returns the size in bytes of the element type T.
+func byteSize[T number.Num]() int {
+	return bitSize[T]() / 8
+}
+
+// encodeNums writes every element of data into b in the given byte order.
+// b must hold len(data)*byteSize[T]() bytes.
+func encodeNums[T number.Num](b []byte, order binary.ByteOrder, data []T) {
+	switch any(data).(type) {
+	case []float32:
+		for i, v := range data {
+			order.PutUint32(b[i*4:], math.Float32bits(float32(v)))
+		}
+	case []float64:
+		for i, v := range data {
+			order.PutUint64(b[i*8:], math.Float64bits(float64(v)))
+		}
+	default:
+		size := byteSize[T]()
+		for i, v := range data {
+			putInt(b[i*size:], order, size, int64(v))
+		}
+	}
+}
+
+// decodeNums reads len(data) elements from b in the given byte order.
+func decodeNums[T number.Num](b []byte, order binary.ByteOrder, data []T) {
+	switch d := any(data).(type) {
+	case []float32:
+		for i := range d {
+			d[i] = math.Float32frombits(order.Uint32(b[i*4:]))
+		}
+	case []float64:
+		for i := range d {
+			d[i] = math.Float64frombits(order.Uint64(b[i*8:]))
+		}
+	default:
+		size := byteSize[T]()
+		for i := range data {
+			data[i] = T(getInt(b[i*size:], order, size))
+		}
+	}
+}
+
+func putInt(b []byte, order binary.ByteOrder, size int, v int64) {
+	switch size {
+	case 1:
+		b[0] = byte(v)
+	case 2:
+		order.PutUint16(b, uint16(v))
+	case 4:
+		order.PutUint32(b, uint32(v))
+	default:
+		order.PutUint64(b, uint64(v))
+	}
+}
+
+func getInt(b []byte, order binary.ByteOrder, size int) int64 {
+	switch size {
+	case 1:
+		return int64(int8(b[0]))
+	case 2:
+		return int64(int16(order.Uint16(b)))
+	case 4:
+		return int64(int32(order.Uint32(b)))
+	default:
+		return int64(order.Uint64(b))
+	}
+}
+
+// readExactly reads n bytes from r. Unlike io.ReadFull into a preallocated buffer it only
+// allocates as data arrives, so a corrupt length field in the input can't exhaust memory.
+func readExactly(r io.Reader, n int64) ([]byte, error) {
+	b, err := io.ReadAll(io.LimitReader(r, n))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) != n {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return b, nil
+}
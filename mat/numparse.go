@@ -0,0 +1,42 @@
+package mat
+
+import (
+	"strconv"
+
+	"github.com/lattots/gonum/number"
+)
+
+// bitSize returns the size in bits of the element type T.
+func bitSize[T number.Num]() int {
+	switch any(*new(T)).(type) {
+	case int8:
+		return 8
+	case int16:
+		return 16
+	case int32, float32:
+		return 32
+	case int:
+		return strconv.IntSize
+	default:
+		return 64
+	}
+}
+
+// parseNum parses s strictly into T. Integer types reject fractional and out of range values.
+func parseNum[T number.Num](s string) (T, error) {
+	if isFloat[T]() {
+		v, err := strconv.ParseFloat(s, bitSize[T]())
+		return T(v), err
+	}
+
+	v, err := strconv.ParseInt(s, 10, bitSize[T]())
+	return T(v), err
+}
+
+// formatNum formats v with the fewest digits needed to parse it back exactly.
+func formatNum[T number.Num](v T) string {
+	if isFloat[T]() {
+		return strconv.FormatFloat(float64(v), 'g', -1, bitSize[T]())
+	}
+	return strconv.FormatInt(int64(v), 10)
+}
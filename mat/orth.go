@@ -0,0 +1,137 @@
+package mat
+
+import (
+	"math"
+
+	"github.com/lattots/gonum/number"
+)
+
+// GramSchmidt orthonormalizes the columns of a from left to right with modified
+// Gram-Schmidt, repeating the orthogonalization once more for every column to keep
+// the result orthogonal to working precision. A column is dropped as linearly dependent
+// if what remains of it is at most tol times the largest column length of a.
+// A tol of 0 or less selects max(M, N) times the machine epsilon of T.
+//
+// The first k columns of the result span the same space as the first independent
+// columns of a. Returns nil if a has no independent columns.
+func GramSchmidt[T number.Float](a *Mat[T], tol float64) *Mat[T] {
+	tol = rankTolerance[T](a, tol)
+
+	var basis []*Mat[T]
+	for _, col := range columns(a) {
+		v := orthogonalize(col, basis)
+		if v.Length() <= tol {
+			continue
+		}
+		basis = append(basis, Normalize(orthogonalize(v, basis)))
+	}
+
+	return fromColumns(basis)
+}
+
+// Orth returns an orthonormal basis of the column space of a. The number of columns of
+// the result is the numerical rank of a, with dependence decided by tol as in GramSchmidt.
+// Unlike GramSchmidt the columns are taken in order of their remaining length, which
+// reveals the rank more reliably. Returns nil if a is zero.
+func Orth[T number.Float](a *Mat[T], tol float64) *Mat[T] {
+	return fromColumns(extendBasis(nil, columns(a), min(a.M, a.N), rankTolerance[T](a, tol)))
+}
+
+// NullSpace returns an orthonormal basis of the null space of a, the vectors x with
+// a x = 0, as the columns of an N x (N - rank) matrix. The rank is decided by tol as
+// in GramSchmidt. Returns nil if a has full column rank.
+func NullSpace[T number.Float](a *Mat[T], tol float64) *Mat[T] {
+	// The null space is the orthogonal complement of the row space
+	at := Transpose(a)
+	rowSpace := extendBasis(nil, columns(at), min(a.M, a.N), rankTolerance[T](at, tol))
+	if len(rowSpace) == a.N {
+		return nil
+	}
+
+	// Complete the basis of the row space with the unit vectors that are furthest from it
+	unit := make([]*Mat[T], a.N)
+	for i := range unit {
+		unit[i], _ = Zeros[T](a.N, 1)
+		unit[i].Data[i] = 1
+	}
+	return fromColumns(extendBasis(rowSpace, unit, a.N-len(rowSpace), 0))
+}
+
+// extendBasis adds up to limit orthonormal vectors from the span of candidates to the
+// orthonormal vectors in basis and returns the added vectors. It picks the candidate
+// with the most left after removing the basis each time, and stops early once that is
+// at most stop.
+func extendBasis[T number.Float](basis, candidates []*Mat[T], limit int, stop float64) []*Mat[T] {
+	residuals := make([]*Mat[T], len(candidates))
+	for i, c := range candidates {
+		residuals[i] = orthogonalize(c, basis)
+	}
+
+	var added []*Mat[T]
+	for len(added) < limit && len(residuals) > 0 {
+		best, bestLen := 0, -1.0
+		for i, r := range residuals {
+			if l := r.Length(); l > bestLen {
+				best, bestLen = i, l
+			}
+		}
+		if bestLen <= stop {
+			break
+		}
+
+		q := Normalize(orthogonalize(residuals[best], basis))
+		basis = append(basis, q)
+		added = append(added, q)
+
+		residuals = append(residuals[:best], residuals[best+1:]...)
+		for i, r := range residuals {
+			residuals[i] = Subtract(r, Scale(q, VectorDot(q, r)))
+		}
+	}
+
+	return added
+}
+
+// orthogonalize removes the components along the orthonormal columns in basis from v
+// one at a time, as in modified Gram-Schmidt.
+func orthogonalize[T number.Float](v *Mat[T], basis []*Mat[T]) *Mat[T] {
+	for _, q := range basis {
+		v = Subtract(v, Scale(q, VectorDot(q, v)))
+	}
+	return v
+}
+
+// rankTolerance returns the length below which a residual column of a counts as zero.
+func rankTolerance[T number.Float](a *Mat[T], tol float64) float64 {
+	if tol <= 0 {
+		eps := math.Pow(2, -52)
+		if bitSize[T]() == 32 {
+			eps = math.Pow(2, -23)
+		}
+		tol = float64(max(a.M, a.N)) * eps
+	}
+
+	var largest float64
+	for _, col := range columns(a) {
+		largest = math.Max(largest, col.Length())
+	}
+	return tol * largest
+}
+
+// columns returns copies of the columns of a as column matrices.
+func columns[T number.Num](a *Mat[T]) []*Mat[T] {
+	cols := make([]*Mat[T], a.N)
+	for j := range cols {
+		cols[j] = SliceCols(a, j, j+1)
+	}
+	return cols
+}
+
+// fromColumns stacks column matrices side by side, or returns nil if there are none.
+func fromColumns[T number.Num](cols []*Mat[T]) *Mat[T] {
+	if len(cols) == 0 {
+		return nil
+	}
+	m, _ := HStack(cols...)
+	return m
+}
@@ -0,0 +1,232 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lattots/gonum/number"
+)
+
+// pairwiseBlock is the lane length below which pairwise summation falls back to a plain loop.
+const pairwiseBlock = 128
+
+// lanes describes how a reduction along an axis walks the matrix data.
+// Lane i starts at i*step and visits n elements that are stride apart.
+type lanes struct {
+	rows, cols int // shape of the reduced result
+	count      int // number of lanes, equal to rows*cols
+	n          int // number of elements in each lane
+	stride     int
+	step       int
+}
+
+func reductionLanes[T number.Num](op string, m *Mat[T], axis Axis) lanes {
+	if len(m.Data) == 0 {
+		panic(fmt.Sprintf("matrix math error: cannot calculate %s of an empty matrix", op))
+	}
+
+	switch axis {
+	case AxisAll:
+		return lanes{rows: 1, cols: 1, count: 1, n: len(m.Data), stride: 1, step: 0}
+	case AxisRows:
+		return lanes{rows: 1, cols: m.N, count: m.N, n: m.M, stride: m.N, step: 1}
+	case AxisCols:
+		return lanes{rows: m.M, cols: 1, count: m.M, n: m.N, stride: 1, step: m.N}
+	default:
+		panic(fmt.Sprintf("matrix math error: invalid axis %d for %s", int(axis), op))
+	}
+}
+
+// reduce applies fn to every lane of m along axis and collects the results into
+// a 1x1, 1xN or Mx1 matrix depending on the axis.
+func reduce[T, R number.Num](op string, m *Mat[T], axis Axis, fn func(data []T, offset, stride, n int) R) *Mat[R] {
+	l := reductionLanes(op, m, axis)
+
+	data := make([]R, l.count)
+	for i := range data {
+		data[i] = fn(m.Data, i*l.step, l.stride, l.n)
+	}
+
+	return &Mat[R]{
+		M:    l.rows,
+		N:    l.cols,
+		Data: data,
+	}
+}
+
+// SumAxis sums the elements of m along axis using the strategy set with SetSummation.
+// The result is 1x1 for AxisAll, 1xN for AxisRows and Mx1 for AxisCols.
+func SumAxis[T number.Num](m *Mat[T], axis Axis) *Mat[T] {
+	return SumAxisWith(m, axis, CurrentSummation())
+}
+
+// SumAxisWith is like SumAxis but uses the summation strategy s. Panics if s is not one
+// of the defined strategies.
+func SumAxisWith[T number.Num](m *Mat[T], axis Axis, s Summation) *Mat[T] {
+	checkSummation(s)
+	return reduce("sum", m, axis, func(data []T, offset, stride, n int) T {
+		return sumStrided(data, offset, stride, n, s)
+	})
+}
+
+// MeanAxis calculates the arithmetic mean of m along axis.
+func MeanAxis[T number.Num](m *Mat[T], axis Axis) *Mat[float64] {
+	return reduce("mean", m, axis, meanLane[T])
+}
+
+// VarAxis calculates the variance of m along axis with ddof delta degrees of freedom.
+// A ddof of 0 gives the population variance and 1 the unbiased sample variance.
+func VarAxis[T number.Num](m *Mat[T], axis Axis, ddof int) *Mat[float64] {
+	return reduce("variance", m, axis, func(data []T, offset, stride, n int) float64 {
+		return varLane(data, offset, stride, n, ddof)
+	})
+}
+
+// StdAxis calculates the standard deviation of m along axis with ddof delta degrees of freedom.
+func StdAxis[T number.Num](m *Mat[T], axis Axis, ddof int) *Mat[float64] {
+	return reduce("standard deviation", m, axis, func(data []T, offset, stride, n int) float64 {
+		return math.Sqrt(varLane(data, offset, stride, n, ddof))
+	})
+}
+
+// ProdAxis multiplies the elements of m along axis.
+func ProdAxis[T number.Num](m *Mat[T], axis Axis) *Mat[T] {
+	return reduce("product", m, axis, func(data []T, offset, stride, n int) T {
+		prod := T(1)
+		for k := 0; k < n; k++ {
+			prod *= data[offset+k*stride]
+		}
+		return prod
+	})
+}
+
+// MinAxis finds the minimum of m along axis.
+func MinAxis[T number.Num](m *Mat[T], axis Axis) *Mat[T] {
+	return reduce("minimum", m, axis, func(data []T, offset, stride, n int) T {
+		return data[offset+argMinLane(data, offset, stride, n)*stride]
+	})
+}
+
+// MaxAxis finds the maximum of m along axis.
+func MaxAxis[T number.Num](m *Mat[T], axis Axis) *Mat[T] {
+	return reduce("maximum", m, axis, func(data []T, offset, stride, n int) T {
+		return data[offset+argMaxLane(data, offset, stride, n)*stride]
+	})
+}
+
+// ArgMinAxis finds the zero-based position of the minimum of m along axis.
+// For AxisRows the result holds row indices, for AxisCols column indices and
+// for AxisAll the row-major index into m.Data. Ties resolve to the first occurrence.
+func ArgMinAxis[T number.Num](m *Mat[T], axis Axis) *Mat[int] {
+	return reduce("argmin", m, axis, argMinLane[T])
+}
+
+// ArgMaxAxis finds the zero-based position of the maximum of m along axis.
+// Indices are reported as in ArgMinAxis.
+func ArgMaxAxis[T number.Num](m *Mat[T], axis Axis) *Mat[int] {
+	return reduce("argmax", m, axis, argMaxLane[T])
+}
+
+// AnyAxis reports with 1 or 0 whether any element of m along axis is non-zero.
+func AnyAxis[T number.Num](m *Mat[T], axis Axis) *Mat[T] {
+	return reduce("any", m, axis, func(data []T, offset, stride, n int) T {
+		for k := 0; k < n; k++ {
+			if data[offset+k*stride] != 0 {
+				return 1
+			}
+		}
+		return 0
+	})
+}
+
+// AllAxis reports with 1 or 0 whether every element of m along axis is non-zero.
+func AllAxis[T number.Num](m *Mat[T], axis Axis) *Mat[T] {
+	return reduce("all", m, axis, func(data []T, offset, stride, n int) T {
+		for k := 0; k < n; k++ {
+			if data[offset+k*stride] == 0 {
+				return 0
+			}
+		}
+		return 1
+	})
+}
+
+// pairwiseSum sums a strided lane by recursively splitting it in halves,
+// which keeps the rounding error growth at O(log n) instead of O(n).
+func pairwiseSum[T number.Num](data []T, offset, stride, n int) T {
+	if n <= pairwiseBlock {
+		var sum T
+		for k := 0; k < n; k++ {
+			sum += data[offset+k*stride]
+		}
+		return sum
+	}
+
+	half := n / 2
+	return pairwiseSum(data, offset, stride, half) + pairwiseSum(data, offset+half*stride, stride, n-half)
+}
+
+// pairwiseSumFloat64 is pairwiseSum with every element converted to float64 first,
+// so that integer lanes can't overflow and float32 lanes keep extra precision.
+func pairwiseSumFloat64[T number.Num](data []T, offset, stride, n int) float64 {
+	if n <= pairwiseBlock {
+		var sum float64
+		for k := 0; k < n; k++ {
+			sum += float64(data[offset+k*stride])
+		}
+		return sum
+	}
+
+	half := n / 2
+	return pairwiseSumFloat64(data, offset, stride, half) + pairwiseSumFloat64(data, offset+half*stride, stride, n-half)
+}
+
+func meanLane[T number.Num](data []T, offset, stride, n int) float64 {
+	return pairwiseSumFloat64(data, offset, stride, n) / float64(n)
+}
+
+// varLane uses the two-pass algorithm: the mean first, then the squared deviations from it.
+func varLane[T number.Num](data []T, offset, stride, n, ddof int) float64 {
+	if n-ddof <= 0 {
+		return math.NaN()
+	}
+
+	mean := meanLane(data, offset, stride, n)
+	sumSq := squaredDeviations(data, offset, stride, n, mean)
+
+	return sumSq / float64(n-ddof)
+}
+
+func squaredDeviations[T number.Num](data []T, offset, stride, n int, mean float64) float64 {
+	if n <= pairwiseBlock {
+		var sum float64
+		for k := 0; k < n; k++ {
+			d := float64(data[offset+k*stride]) - mean
+			sum += d * d
+		}
+		return sum
+	}
+
+	half := n / 2
+	return squaredDeviations(data, offset, stride, half, mean) + squaredDeviations(data, offset+half*stride, stride, n-half, mean)
+}
+
+func argMinLane[T number.Num](data []T, offset, stride, n int) int {
+	best := 0
+	for k := 1; k < n; k++ {
+		if data[offset+k*stride] < data[offset+best*stride] {
+			best = k
+		}
+	}
+	return best
+}
+
+func argMaxLane[T number.Num](data []T, offset, stride, n int) int {
+	best := 0
+	for k := 1; k < n; k++ {
+		if data[offset+k*stride] > data[offset+best*stride] {
+			best = k
+		}
+	}
+	return best
+}
@@ -0,0 +1,203 @@
+package mat
+
+import (
+	"fmt"
+
+	"github.com/lattots/gonum/number"
+)
+
+// SliceCols returns a copy of the columns in the half-open range [start, end).
+// Like SliceRows, out of range bounds are clamped to the matrix. Panics if the
+// resulting range is empty.
+func SliceCols[T number.Num](m *Mat[T], start, end int) *Mat[T] {
+	if start < 0 {
+		start = 0
+	}
+	if end > m.N {
+		end = m.N
+	}
+	if start >= end {
+		panic("start column index can't be greater than end index")
+	}
+
+	slicedCols := end - start
+	data := make([]T, m.M*slicedCols)
+	for r := 0; r < m.M; r++ {
+		copy(data[r*slicedCols:(r+1)*slicedCols], m.Data[r*m.N+start:r*m.N+end])
+	}
+
+	return &Mat[T]{
+		M:    m.M,
+		N:    slicedCols,
+		Data: data,
+	}
+}
+
+// Sub returns a copy of the submatrix made of rows [r0, r1) and columns [c0, c1).
+// Bounds are clamped to the matrix. Panics if either range is empty.
+func Sub[T number.Num](m *Mat[T], r0, r1, c0, c1 int) *Mat[T] {
+	if r0 < 0 {
+		r0 = 0
+	}
+	if r1 > m.M {
+		r1 = m.M
+	}
+	if c0 < 0 {
+		c0 = 0
+	}
+	if c1 > m.N {
+		c1 = m.N
+	}
+	if r0 >= r1 {
+		panic("start row index can't be greater than end index")
+	}
+	if c0 >= c1 {
+		panic("start column index can't be greater than end index")
+	}
+
+	rows := r1 - r0
+	cols := c1 - c0
+	data := make([]T, rows*cols)
+	for r := 0; r < rows; r++ {
+		srcStart := (r0+r)*m.N + c0
+		copy(data[r*cols:(r+1)*cols], m.Data[srcStart:srcStart+cols])
+	}
+
+	return &Mat[T]{
+		M:    rows,
+		N:    cols,
+		Data: data,
+	}
+}
+
+// SelectRows returns a copy of the rows at the given zero-based indices, in the
+// order they are listed. Indices may repeat. Panics if idx is empty or an
+// index is out of range.
+func SelectRows[T number.Num](m *Mat[T], idx []int) *Mat[T] {
+	if len(idx) == 0 {
+		panic("matrix index error: at least one row must be selected")
+	}
+
+	data := make([]T, len(idx)*m.N)
+	for i, r := range idx {
+		if r < 0 || r >= m.M {
+			panic(fmt.Sprintf("matrix index error: row index %d out of range for %dx%d matrix", r, m.M, m.N))
+		}
+		copy(data[i*m.N:(i+1)*m.N], m.Data[r*m.N:(r+1)*m.N])
+	}
+
+	return &Mat[T]{
+		M:    len(idx),
+		N:    m.N,
+		Data: data,
+	}
+}
+
+// SelectCols returns a copy of the columns at the given zero-based indices, in
+// the order they are listed. Indices may repeat. Panics if idx is empty or an
+// index is out of range.
+func SelectCols[T number.Num](m *Mat[T], idx []int) *Mat[T] {
+	if len(idx) == 0 {
+		panic("matrix index error: at least one column must be selected")
+	}
+	for _, c := range idx {
+		if c < 0 || c >= m.N {
+			panic(fmt.Sprintf("matrix index error: column index %d out of range for %dx%d matrix", c, m.M, m.N))
+		}
+	}
+
+	cols := len(idx)
+	data := make([]T, m.M*cols)
+	for r := 0; r < m.M; r++ {
+		for i, c := range idx {
+			data[r*cols+i] = m.Data[r*m.N+c]
+		}
+	}
+
+	return &Mat[T]{
+		M:    m.M,
+		N:    cols,
+		Data: data,
+	}
+}
+
+// MaskRows returns a copy of the rows whose entry in mask is true.
+// Panics if the mask length differs from the number of rows or no row is selected.
+func MaskRows[T number.Num](m *Mat[T], mask []bool) *Mat[T] {
+	if len(mask) != m.M {
+		panic(fmt.Sprintf("matrix index error: row mask has %d entries for a matrix with %d rows", len(mask), m.M))
+	}
+	return SelectRows(m, maskIndices(mask))
+}
+
+// MaskCols returns a copy of the columns whose entry in mask is true.
+// Panics if the mask length differs from the number of columns or no column is selected.
+func MaskCols[T number.Num](m *Mat[T], mask []bool) *Mat[T] {
+	if len(mask) != m.N {
+		panic(fmt.Sprintf("matrix index error: column mask has %d entries for a matrix with %d columns", len(mask), m.N))
+	}
+	return SelectCols(m, maskIndices(mask))
+}
+
+// SetSub writes src into dst in place, with the top-left element of src
+// placed at zero-based row r0 and column c0 of dst.
+// Panics if src does not fit inside dst at that position.
+func SetSub[T number.Num](dst *Mat[T], r0, c0 int, src *Mat[T]) {
+	if r0 < 0 || c0 < 0 || r0+src.M > dst.M || c0+src.N > dst.N {
+		panic(fmt.Sprintf("matrix index error: %dx%d submatrix at (%d, %d) does not fit in %dx%d matrix", src.M, src.N, r0, c0, dst.M, dst.N))
+	}
+
+	for r := 0; r < src.M; r++ {
+		dstStart := (r0+r)*dst.N + c0
+		copy(dst.Data[dstStart:dstStart+src.N], src.Data[r*src.N:(r+1)*src.N])
+	}
+}
+
+// SetRows writes the rows of src into the rows of dst at the given zero-based
+// indices, in place. Row i of src is written to row idx[i] of dst.
+// Panics if the shapes don't match or an index is out of range.
+func SetRows[T number.Num](dst *Mat[T], idx []int, src *Mat[T]) {
+	if src.M != len(idx) || src.N != dst.N {
+		panic(fmt.Sprintf("matrix index error: cannot write %dx%d matrix into %d rows of %dx%d matrix", src.M, src.N, len(idx), dst.M, dst.N))
+	}
+	for _, r := range idx {
+		if r < 0 || r >= dst.M {
+			panic(fmt.Sprintf("matrix index error: row index %d out of range for %dx%d matrix", r, dst.M, dst.N))
+		}
+	}
+
+	for i, r := range idx {
+		copy(dst.Data[r*dst.N:(r+1)*dst.N], src.Data[i*src.N:(i+1)*src.N])
+	}
+}
+
+// SetCols writes the columns of src into the columns of dst at the given
+// zero-based indices, in place. Column i of src is written to column idx[i] of dst.
+// Panics if the shapes don't match or an index is out of range.
+func SetCols[T number.Num](dst *Mat[T], idx []int, src *Mat[T]) {
+	if src.N != len(idx) || src.M != dst.M {
+		panic(fmt.Sprintf("matrix index error: cannot write %dx%d matrix into %d columns of %dx%d matrix", src.M, src.N, len(idx), dst.M, dst.N))
+	}
+	for _, c := range idx {
+		if c < 0 || c >= dst.N {
+			panic(fmt.Sprintf("matrix index error: column index %d out of range for %dx%d matrix", c, dst.M, dst.N))
+		}
+	}
+
+	for r := 0; r < dst.M; r++ {
+		for i, c := range idx {
+			dst.Data[r*dst.N+c] = src.Data[r*src.N+i]
+		}
+	}
+}
+
+// maskIndices returns the indices of all true entries in mask.
+func maskIndices(mask []bool) []int {
+	var idx []int
+	for i, ok := range mask {
+		if ok {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
@@ -0,0 +1,267 @@
+package mat
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lattots/gonum/number"
+)
+
+// ErrSingular is returned when inverting a matrix whose determinant is zero.
+var ErrSingular = errors.New("matrix math error: matrix is singular")
+
+// Mat2, Mat3 and Mat4 are fixed-size square matrices for graphics and kinematics, stored
+// row-major in arrays. They are values that live on the stack, so none of their methods
+// allocate and copying one copies the elements. Sums are accumulated left to right
+// regardless of SetSummation, which makes no difference at these sizes for the default
+// pairwise strategy.
+type (
+	Mat2[T number.Float] [4]T
+	Mat3[T number.Float] [9]T
+	Mat4[T number.Float] [16]T
+)
+
+// Identity2 returns the 2x2 identity matrix.
+func Identity2[T number.Float]() Mat2[T] {
+	return Mat2[T]{1, 0, 0, 1}
+}
+
+// Identity3 returns the 3x3 identity matrix.
+func Identity3[T number.Float]() Mat3[T] {
+	return Mat3[T]{1, 0, 0, 0, 1, 0, 0, 0, 1}
+}
+
+// Identity4 returns the 4x4 identity matrix.
+func Identity4[T number.Float]() Mat4[T] {
+	return Mat4[T]{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}
+}
+
+// Mat2From copies a 2x2 matrix into a Mat2.
+func Mat2From[T number.Float](m *Mat[T]) (Mat2[T], error) {
+	var r Mat2[T]
+	return r, copyFixed(r[:], 2, m)
+}
+
+// Mat3From copies a 3x3 matrix into a Mat3.
+func Mat3From[T number.Float](m *Mat[T]) (Mat3[T], error) {
+	var r Mat3[T]
+	return r, copyFixed(r[:], 3, m)
+}
+
+// Mat4From copies a 4x4 matrix into a Mat4.
+func Mat4From[T number.Float](m *Mat[T]) (Mat4[T], error) {
+	var r Mat4[T]
+	return r, copyFixed(r[:], 4, m)
+}
+
+func copyFixed[T number.Float](dst []T, n int, m *Mat[T]) error {
+	if m.M != n || m.N != n {
+		return shapeErrorf(fmt.Sprintf("Mat%dFrom", n), "need a %dx%d matrix, got %dx%d", n, n, m.M, m.N)
+	}
+	copy(dst, m.Data)
+	return nil
+}
+
+// Mat returns a copy of m as a general matrix.
+func (m Mat2[T]) Mat() *Mat[T] {
+	return &Mat[T]{M: 2, N: 2, Data: append([]T(nil), m[:]...)}
+}
+
+// Mat returns a copy of m as a general matrix.
+func (m Mat3[T]) Mat() *Mat[T] {
+	return &Mat[T]{M: 3, N: 3, Data: append([]T(nil), m[:]...)}
+}
+
+// Mat returns a copy of m as a general matrix.
+func (m Mat4[T]) Mat() *Mat[T] {
+	return &Mat[T]{M: 4, N: 4, Data: append([]T(nil), m[:]...)}
+}
+
+// Mul calculates the matrix product m n.
+func (m Mat2[T]) Mul(n Mat2[T]) Mat2[T] {
+	return Mat2[T]{
+		m[0]*n[0] + m[1]*n[2], m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2], m[2]*n[1] + m[3]*n[3],
+	}
+}
+
+// Mul calculates the matrix product m n.
+func (m Mat3[T]) Mul(n Mat3[T]) Mat3[T] {
+	var r Mat3[T]
+	for i := 0; i < 9; i += 3 {
+		a0, a1, a2 := m[i], m[i+1], m[i+2]
+		r[i] = a0*n[0] + a1*n[3] + a2*n[6]
+		r[i+1] = a0*n[1] + a1*n[4] + a2*n[7]
+		r[i+2] = a0*n[2] + a1*n[5] + a2*n[8]
+	}
+	return r
+}
+
+// Mul calculates the matrix product m n.
+func (m Mat4[T]) Mul(n Mat4[T]) Mat4[T] {
+	var r Mat4[T]
+	for i := 0; i < 16; i += 4 {
+		a0, a1, a2, a3 := m[i], m[i+1], m[i+2], m[i+3]
+		r[i] = a0*n[0] + a1*n[4] + a2*n[8] + a3*n[12]
+		r[i+1] = a0*n[1] + a1*n[5] + a2*n[9] + a3*n[13]
+		r[i+2] = a0*n[2] + a1*n[6] + a2*n[10] + a3*n[14]
+		r[i+3] = a0*n[3] + a1*n[7] + a2*n[11] + a3*n[15]
+	}
+	return r
+}
+
+// MulVec calculates the product of m and the column vector v.
+func (m Mat2[T]) MulVec(v [2]T) [2]T {
+	return [2]T{
+		m[0]*v[0] + m[1]*v[1],
+		m[2]*v[0] + m[3]*v[1],
+	}
+}
+
+// MulVec calculates the product of m and the column vector v.
+func (m Mat3[T]) MulVec(v [3]T) [3]T {
+	return [3]T{
+		m[0]*v[0] + m[1]*v[1] + m[2]*v[2],
+		m[3]*v[0] + m[4]*v[1] + m[5]*v[2],
+		m[6]*v[0] + m[7]*v[1] + m[8]*v[2],
+	}
+}
+
+// MulVec calculates the product of m and the column vector v.
+func (m Mat4[T]) MulVec(v [4]T) [4]T {
+	return [4]T{
+		m[0]*v[0] + m[1]*v[1] + m[2]*v[2] + m[3]*v[3],
+		m[4]*v[0] + m[5]*v[1] + m[6]*v[2] + m[7]*v[3],
+		m[8]*v[0] + m[9]*v[1] + m[10]*v[2] + m[11]*v[3],
+		m[12]*v[0] + m[13]*v[1] + m[14]*v[2] + m[15]*v[3],
+	}
+}
+
+// Transpose returns the transpose of m.
+func (m Mat2[T]) Transpose() Mat2[T] {
+	return Mat2[T]{m[0], m[2], m[1], m[3]}
+}
+
+// Transpose returns the transpose of m.
+func (m Mat3[T]) Transpose() Mat3[T] {
+	return Mat3[T]{
+		m[0], m[3], m[6],
+		m[1], m[4], m[7],
+		m[2], m[5], m[8],
+	}
+}
+
+// Transpose returns the transpose of m.
+func (m Mat4[T]) Transpose() Mat4[T] {
+	return Mat4[T]{
+		m[0], m[4], m[8], m[12],
+		m[1], m[5], m[9], m[13],
+		m[2], m[6], m[10], m[14],
+		m[3], m[7], m[11], m[15],
+	}
+}
+
+// Det calculates the determinant of m.
+func (m Mat2[T]) Det() T {
+	return m[0]*m[3] - m[1]*m[2]
+}
+
+// Det calculates the determinant of m by cofactor expansion along the first row.
+func (m Mat3[T]) Det() T {
+	return m[0]*(m[4]*m[8]-m[5]*m[7]) -
+		m[1]*(m[3]*m[8]-m[5]*m[6]) +
+		m[2]*(m[3]*m[7]-m[4]*m[6])
+}
+
+// Det calculates the determinant of m from the 2x2 minors of its upper and lower halves.
+func (m Mat4[T]) Det() T {
+	s, c := m.minors()
+	return s[0]*c[5] - s[1]*c[4] + s[2]*c[3] + s[3]*c[2] - s[4]*c[1] + s[5]*c[0]
+}
+
+// minors returns the six 2x2 determinants of the top two rows and the six of the
+// bottom two rows, for the columns pairs 01, 02, 03, 12, 13 and 23.
+func (m Mat4[T]) minors() (s, c [6]T) {
+	s = [6]T{
+		m[0]*m[5] - m[4]*m[1],
+		m[0]*m[6] - m[4]*m[2],
+		m[0]*m[7] - m[4]*m[3],
+		m[1]*m[6] - m[5]*m[2],
+		m[1]*m[7] - m[5]*m[3],
+		m[2]*m[7] - m[6]*m[3],
+	}
+	c = [6]T{
+		m[8]*m[13] - m[12]*m[9],
+		m[8]*m[14] - m[12]*m[10],
+		m[8]*m[15] - m[12]*m[11],
+		m[9]*m[14] - m[13]*m[10],
+		m[9]*m[15] - m[13]*m[11],
+		m[10]*m[15] - m[14]*m[11],
+	}
+	return s, c
+}
+
+// Inverse calculates the inverse of m from its adjugate. Returns ErrSingular if the
+// determinant is zero. Nearly singular matrices give inaccurate results.
+func (m Mat2[T]) Inverse() (Mat2[T], error) {
+	det := m.Det()
+	if det == 0 {
+		return Mat2[T]{}, ErrSingular
+	}
+	inv := 1 / det
+	return Mat2[T]{
+		m[3] * inv, -m[1] * inv,
+		-m[2] * inv, m[0] * inv,
+	}, nil
+}
+
+// Inverse calculates the inverse of m from its adjugate. Returns ErrSingular if the
+// determinant is zero. Nearly singular matrices give inaccurate results.
+func (m Mat3[T]) Inverse() (Mat3[T], error) {
+	c0 := m[4]*m[8] - m[5]*m[7]
+	c1 := m[5]*m[6] - m[3]*m[8]
+	c2 := m[3]*m[7] - m[4]*m[6]
+	det := m[0]*c0 + m[1]*c1 + m[2]*c2
+	if det == 0 {
+		return Mat3[T]{}, ErrSingular
+	}
+	inv := 1 / det
+	return Mat3[T]{
+		c0 * inv, (m[2]*m[7] - m[1]*m[8]) * inv, (m[1]*m[5] - m[2]*m[4]) * inv,
+		c1 * inv, (m[0]*m[8] - m[2]*m[6]) * inv, (m[2]*m[3] - m[0]*m[5]) * inv,
+		c2 * inv, (m[1]*m[6] - m[0]*m[7]) * inv, (m[0]*m[4] - m[1]*m[3]) * inv,
+	}, nil
+}
+
+// Inverse calculates the inverse of m from its adjugate, built from the same 2x2 minors
+// as Det. Returns ErrSingular if the determinant is zero. Nearly singular matrices give
+// inaccurate results.
+func (m Mat4[T]) Inverse() (Mat4[T], error) {
+	s, c := m.minors()
+	det := s[0]*c[5] - s[1]*c[4] + s[2]*c[3] + s[3]*c[2] - s[4]*c[1] + s[5]*c[0]
+	if det == 0 {
+		return Mat4[T]{}, ErrSingular
+	}
+	inv := 1 / det
+	return Mat4[T]{
+		(m[5]*c[5] - m[6]*c[4] + m[7]*c[3]) * inv,
+		(-m[1]*c[5] + m[2]*c[4] - m[3]*c[3]) * inv,
+		(m[13]*s[5] - m[14]*s[4] + m[15]*s[3]) * inv,
+		(-m[9]*s[5] + m[10]*s[4] - m[11]*s[3]) * inv,
+
+		(-m[4]*c[5] + m[6]*c[2] - m[7]*c[1]) * inv,
+		(m[0]*c[5] - m[2]*c[2] + m[3]*c[1]) * inv,
+		(-m[12]*s[5] + m[14]*s[2] - m[15]*s[1]) * inv,
+		(m[8]*s[5] - m[10]*s[2] + m[11]*s[1]) * inv,
+
+		(m[4]*c[4] - m[5]*c[2] + m[7]*c[0]) * inv,
+		(-m[0]*c[4] + m[1]*c[2] - m[3]*c[0]) * inv,
+		(m[12]*s[4] - m[13]*s[2] + m[15]*s[0]) * inv,
+		(-m[8]*s[4] + m[9]*s[2] - m[11]*s[0]) * inv,
+
+		(-m[4]*c[3] + m[5]*c[1] - m[6]*c[0]) * inv,
+		(m[0]*c[3] - m[1]*c[1] + m[2]*c[0]) * inv,
+		(-m[12]*s[3] + m[13]*s[1] - m[14]*s[0]) * inv,
+		(m[8]*s[3] - m[9]*s[1] + m[10]*s[0]) * inv,
+	}, nil
+}
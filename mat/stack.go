@@ -0,0 +1,218 @@
+package mat
+
+import (
+	"math"
+
+	"github.com/lattots/gonum/number"
+)
+
+// HStack joins matrices side by side. All matrices must have the same number of rows.
+func HStack[T number.Num](ms ...*Mat[T]) (*Mat[T], error) {
+	if len(ms) == 0 {
+		return nil, shapeErrorf("HStack", "at least one matrix is required")
+	}
+
+	rows := ms[0].M
+	cols := 0
+	for i, m := range ms {
+		if m.M != rows {
+			return nil, shapeErrorf("HStack", "matrix %d has %d rows, expected %d", i, m.M, rows)
+		}
+		cols += m.N
+	}
+
+	data := make([]T, rows*cols)
+	offset := 0
+	for _, m := range ms {
+		for r := 0; r < rows; r++ {
+			copy(data[r*cols+offset:r*cols+offset+m.N], m.Data[r*m.N:(r+1)*m.N])
+		}
+		offset += m.N
+	}
+
+	return &Mat[T]{
+		M:    rows,
+		N:    cols,
+		Data: data,
+	}, nil
+}
+
+// VStack joins matrices on top of each other. All matrices must have the same number of columns.
+func VStack[T number.Num](ms ...*Mat[T]) (*Mat[T], error) {
+	if len(ms) == 0 {
+		return nil, shapeErrorf("VStack", "at least one matrix is required")
+	}
+
+	cols := ms[0].N
+	rows := 0
+	for i, m := range ms {
+		if m.N != cols {
+			return nil, shapeErrorf("VStack", "matrix %d has %d columns, expected %d", i, m.N, cols)
+		}
+		rows += m.M
+	}
+
+	data := make([]T, 0, rows*cols)
+	for _, m := range ms {
+		data = append(data, m.Data...)
+	}
+
+	return &Mat[T]{
+		M:    rows,
+		N:    cols,
+		Data: data,
+	}, nil
+}
+
+// BlockDiag builds a block diagonal matrix with the given matrices on the
+// diagonal and zeros everywhere else.
+func BlockDiag[T number.Num](ms ...*Mat[T]) (*Mat[T], error) {
+	if len(ms) == 0 {
+		return nil, shapeErrorf("BlockDiag", "at least one matrix is required")
+	}
+
+	rows, cols := 0, 0
+	for _, m := range ms {
+		rows += m.M
+		cols += m.N
+	}
+
+	result, err := Zeros[T](rows, cols)
+	if err != nil {
+		return nil, err
+	}
+
+	r0, c0 := 0, 0
+	for _, m := range ms {
+		SetSub(result, r0, c0, m)
+		r0 += m.M
+		c0 += m.N
+	}
+
+	return result, nil
+}
+
+// Reshape returns a copy of m with the elements laid out in rows x cols, keeping
+// row-major order. One of the dimensions may be -1, in which case it is inferred
+// from the number of elements.
+func Reshape[T number.Num](m *Mat[T], rows, cols int) (*Mat[T], error) {
+	size := len(m.Data)
+
+	switch {
+	case rows == -1 && cols == -1:
+		return nil, shapeErrorf("Reshape", "only one dimension can be inferred")
+	case rows == -1 && cols > 0:
+		if size%cols != 0 {
+			return nil, shapeErrorf("Reshape", "cannot reshape %dx%d matrix into %d columns", m.M, m.N, cols)
+		}
+		rows = size / cols
+	case cols == -1 && rows > 0:
+		if size%rows != 0 {
+			return nil, shapeErrorf("Reshape", "cannot reshape %dx%d matrix into %d rows", m.M, m.N, rows)
+		}
+		cols = size / rows
+	}
+
+	if rows <= 0 || cols <= 0 {
+		return nil, shapeErrorf("Reshape", "dimensions must be above zero, got %dx%d", rows, cols)
+	}
+	// rows*cols could wrap around to the number of elements
+	if rows > math.MaxInt/cols {
+		return nil, shapeErrorf("Reshape", "matrix dimensions %dx%d are too large", rows, cols)
+	}
+	if rows*cols != size {
+		return nil, shapeErrorf("Reshape", "cannot reshape %dx%d matrix into %dx%d", m.M, m.N, rows, cols)
+	}
+
+	data := make([]T, size)
+	copy(data, m.Data)
+
+	return &Mat[T]{
+		M:    rows,
+		N:    cols,
+		Data: data,
+	}, nil
+}
+
+// Flatten returns a copy of m as a 1xMN row vector in row-major order.
+func Flatten[T number.Num](m *Mat[T]) *Mat[T] {
+	data := make([]T, len(m.Data))
+	copy(data, m.Data)
+
+	return &Mat[T]{
+		M:    1,
+		N:    len(data),
+		Data: data,
+	}
+}
+
+// Tile repeats the whole matrix rowReps times vertically and colReps times horizontally.
+func Tile[T number.Num](m *Mat[T], rowReps, colReps int) (*Mat[T], error) {
+	if rowReps <= 0 || colReps <= 0 {
+		return nil, shapeErrorf("Tile", "repetitions must be above zero, got %dx%d", rowReps, colReps)
+	}
+	if m.M > math.MaxInt/rowReps || m.N > math.MaxInt/colReps || m.M*rowReps > math.MaxInt/(m.N*colReps) {
+		return nil, shapeErrorf("Tile", "tiling %dx%d matrix %dx%d times is too large", m.M, m.N, rowReps, colReps)
+	}
+
+	cols := m.N * colReps
+	data := make([]T, m.M*rowReps*cols)
+
+	for r := 0; r < m.M; r++ {
+		row := m.Data[r*m.N : (r+1)*m.N]
+		dstRow := data[r*cols : (r+1)*cols]
+		for c := 0; c < colReps; c++ {
+			copy(dstRow[c*m.N:], row)
+		}
+	}
+
+	// The first block of rows is complete, copy it down for the remaining repetitions.
+	block := m.M * cols
+	for rep := 1; rep < rowReps; rep++ {
+		copy(data[rep*block:(rep+1)*block], data[:block])
+	}
+
+	return &Mat[T]{
+		M:    m.M * rowReps,
+		N:    cols,
+		Data: data,
+	}, nil
+}
+
+// Repeat repeats each element of m k times along the given axis.
+// With AxisRows every row is repeated k times in place, with AxisCols every column,
+// and with AxisAll the matrix is flattened and every element repeated, giving a row vector.
+func Repeat[T number.Num](m *Mat[T], k int, axis Axis) (*Mat[T], error) {
+	if k <= 0 {
+		return nil, shapeErrorf("Repeat", "repetitions must be above zero, got %d", k)
+	}
+	if len(m.Data) > math.MaxInt/k {
+		return nil, shapeErrorf("Repeat", "repeating %dx%d matrix %d times is too large", m.M, m.N, k)
+	}
+
+	switch axis {
+	case AxisRows:
+		data := make([]T, 0, len(m.Data)*k)
+		for r := 0; r < m.M; r++ {
+			row := m.Data[r*m.N : (r+1)*m.N]
+			for range k {
+				data = append(data, row...)
+			}
+		}
+		return &Mat[T]{M: m.M * k, N: m.N, Data: data}, nil
+	case AxisCols, AxisAll:
+		// Repeating every element in row-major order repeats every column in place.
+		data := make([]T, 0, len(m.Data)*k)
+		for _, val := range m.Data {
+			for range k {
+				data = append(data, val)
+			}
+		}
+		if axis == AxisAll {
+			return &Mat[T]{M: 1, N: len(data), Data: data}, nil
+		}
+		return &Mat[T]{M: m.M, N: m.N * k, Data: data}, nil
+	default:
+		return nil, shapeErrorf("Repeat", "invalid axis %d", int(axis))
+	}
+}
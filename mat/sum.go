@@ -6,10 +6,16 @@ import (
 	"github.com/lattots/gonum/number"
 )
 
-// Sum adds m2 to m1 element-wise. Panics if dimensions mismatch.
-func Sum[T number.Num](m1, m2 *Mat[T]) *Mat[T] {
+// Sum adds b to a element-wise, broadcasting compatible shapes (see BroadcastShape).
+// Panics if the dimensions can't be broadcast together.
+func Sum[T number.Num](a, b Matrix[T]) *Mat[T] {
+	m1, m2 := DenseOf(a), DenseOf(b)
 	if m1.M != m2.M || m1.N != m2.N {
-		panic(fmt.Sprintf("matrix math error: cannot sum matrices with different dimensions (%dx%d and %dx%d)", m1.M, m1.N, m2.M, m2.N))
+		result, err := broadcast("Sum", m1, m2, func(a, b T) T { return a + b })
+		if err != nil {
+			panic(fmt.Sprintf("matrix math error: cannot sum matrices with incompatible dimensions (%dx%d and %dx%d)", m1.M, m1.N, m2.M, m2.N))
+		}
+		return result
 	}
 
 	data := make([]T, len(m1.Data))
@@ -24,10 +30,16 @@ func Sum[T number.Num](m1, m2 *Mat[T]) *Mat[T] {
 	}
 }
 
-// Subtract subtracts m2 from m1 element-wise. Panics if dimensions mismatch.
-func Subtract[T number.Num](m1, m2 *Mat[T]) *Mat[T] {
+// Subtract subtracts b from a element-wise, broadcasting compatible shapes (see BroadcastShape).
+// Panics if the dimensions can't be broadcast together.
+func Subtract[T number.Num](a, b Matrix[T]) *Mat[T] {
+	m1, m2 := DenseOf(a), DenseOf(b)
 	if m1.M != m2.M || m1.N != m2.N {
-		panic(fmt.Sprintf("matrix math error: cannot subtract matrices with different dimensions (%dx%d and %dx%d)", m1.M, m1.N, m2.M, m2.N))
+		result, err := broadcast("Subtract", m1, m2, func(a, b T) T { return a - b })
+		if err != nil {
+			panic(fmt.Sprintf("matrix math error: cannot subtract matrices with incompatible dimensions (%dx%d and %dx%d)", m1.M, m1.N, m2.M, m2.N))
+		}
+		return result
 	}
 
 	data := make([]T, len(m1.Data))
@@ -48,18 +60,7 @@ func SumRows[T number.Num](m *Mat[T]) *Mat[T] {
 		panic("matrix math error: matrix must have at least one row to sum")
 	}
 
-	data := make([]T, m.N)
-	for r := 0; r < m.M; r++ {
-		for c := 0; c < m.N; c++ {
-			data[c] += m.Data[r*m.N+c]
-		}
-	}
-
-	return &Mat[T]{
-		M:    1,
-		N:    m.N,
-		Data: data,
-	}
+	return SumAxis(m, AxisRows)
 }
 
 // SumColumns collapses all columns into a single Mx1 column vector.
@@ -68,18 +69,7 @@ func SumColumns[T number.Num](m *Mat[T]) *Mat[T] {
 		panic("matrix math error: matrix must have at least one column to sum")
 	}
 
-	data := make([]T, m.M)
-	for r := 0; r < m.M; r++ {
-		for c := 0; c < m.N; c++ {
-			data[r] += m.Data[r*m.N+c]
-		}
-	}
-
-	return &Mat[T]{
-		M:    m.M,
-		N:    1,
-		Data: data,
-	}
+	return SumAxis(m, AxisCols)
 }
 
 // AddRowVector adds a 1xN row vector to every row of an MxN matrix.
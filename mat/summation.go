@@ -0,0 +1,158 @@
+package mat
+
+import (
+	"sync/atomic"
+
+	"github.com/lattots/gonum/number"
+)
+
+// Summation selects how floating point sums are accumulated in reductions and dot products.
+// Integer arithmetic is exact, so integer matrices always use plain accumulation.
+type Summation int32
+
+const (
+	// PairwiseSummation recursively splits the sum in halves. Its error grows with
+	// O(log n) and it costs about as much as a plain loop. This is the default.
+	PairwiseSummation Summation = iota
+	// NaiveSummation accumulates left to right in the element type. It is the
+	// fastest mode, but its error grows with O(n).
+	NaiveSummation
+	// KahanSummation uses Neumaier's variant of compensated summation, which keeps
+	// the error independent of n at roughly four times the cost of a plain loop.
+	KahanSummation
+	// Float64Summation accumulates float32 values in float64 and rounds once at the end.
+	// For float64 values it is the same as NaiveSummation.
+	Float64Summation
+)
+
+func (s Summation) String() string {
+	switch s {
+	case PairwiseSummation:
+		return "pairwise"
+	case NaiveSummation:
+		return "naive"
+	case KahanSummation:
+		return "kahan"
+	case Float64Summation:
+		return "float64"
+	default:
+		return "invalid summation"
+	}
+}
+
+var summation atomic.Int32
+
+// SetSummation sets the default accumulation strategy used by SumAxis, SumRows,
+// SumColumns, VectorDot, MulVec, Vec.Dot and Dot, and returns the previous one. The
+// default is shared by the whole program, so libraries should leave it to the program
+// and pass a strategy to SumAxisWith, VectorDotWith, MulVecWith, Vec.DotWith or DotWith
+// instead. It is safe for concurrent use, but operations that are already running keep
+// the strategy they started with. Panics if s is not one of the defined strategies.
+func SetSummation(s Summation) Summation {
+	checkSummation(s)
+	return Summation(summation.Swap(int32(s)))
+}
+
+func checkSummation(s Summation) {
+	if s < PairwiseSummation || s > Float64Summation {
+		panic("matrix math error: invalid summation strategy")
+	}
+}
+
+// CurrentSummation returns the default accumulation strategy set with SetSummation.
+func CurrentSummation() Summation {
+	return Summation(summation.Load())
+}
+
+// sumStrided sums n elements of data starting at offset and stride apart using strategy s.
+func sumStrided[T number.Num](data []T, offset, stride, n int, s Summation) T {
+	if !isFloat[T]() {
+		s = NaiveSummation
+	}
+
+	switch s {
+	case PairwiseSummation:
+		return pairwiseSum(data, offset, stride, n)
+	case KahanSummation:
+		var sum, c T
+		for k := 0; k < n; k++ {
+			sum, c = neumaierStep(sum, c, data[offset+k*stride])
+		}
+		return sum + c
+	case Float64Summation:
+		var sum float64
+		for k := 0; k < n; k++ {
+			sum += float64(data[offset+k*stride])
+		}
+		return T(sum)
+	default:
+		var sum T
+		for k := 0; k < n; k++ {
+			sum += data[offset+k*stride]
+		}
+		return sum
+	}
+}
+
+// dotStrided calculates the dot product of two strided lanes of length n using strategy s.
+func dotStrided[T number.Num](a []T, aOffset, aStride int, b []T, bOffset, bStride, n int, s Summation) T {
+	if !isFloat[T]() {
+		s = NaiveSummation
+	}
+
+	switch s {
+	case PairwiseSummation:
+		return pairwiseDot(a, aOffset, aStride, b, bOffset, bStride, n)
+	case KahanSummation:
+		var sum, c T
+		for k := 0; k < n; k++ {
+			sum, c = neumaierStep(sum, c, a[aOffset+k*aStride]*b[bOffset+k*bStride])
+		}
+		return sum + c
+	case Float64Summation:
+		var sum float64
+		for k := 0; k < n; k++ {
+			sum += float64(a[aOffset+k*aStride]) * float64(b[bOffset+k*bStride])
+		}
+		return T(sum)
+	default:
+		var sum T
+		for k := 0; k < n; k++ {
+			sum += a[aOffset+k*aStride] * b[bOffset+k*bStride]
+		}
+		return sum
+	}
+}
+
+func pairwiseDot[T number.Num](a []T, aOffset, aStride int, b []T, bOffset, bStride, n int) T {
+	if n <= pairwiseBlock {
+		var sum T
+		for k := 0; k < n; k++ {
+			sum += a[aOffset+k*aStride] * b[bOffset+k*bStride]
+		}
+		return sum
+	}
+
+	half := n / 2
+	return pairwiseDot(a, aOffset, aStride, b, bOffset, bStride, half) +
+		pairwiseDot(a, aOffset+half*aStride, aStride, b, bOffset+half*bStride, bStride, n-half)
+}
+
+// neumaierStep adds x to the running sum and returns the new sum and compensation.
+// Unlike the original Kahan algorithm it stays accurate when x is larger than the sum.
+func neumaierStep[T number.Num](sum, c, x T) (T, T) {
+	t := sum + x
+	if abs(sum) >= abs(x) {
+		c += (sum - t) + x
+	} else {
+		c += (x - t) + sum
+	}
+	return t, c
+}
+
+func abs[T number.Num](x T) T {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,77 @@
+package mat
+
+import (
+	"fmt"
+
+	"github.com/lattots/gonum/number"
+)
+
+// SymDense is an n x n symmetric matrix such as a covariance matrix. Only the upper
+// triangle is stored, packed row by row, which takes n(n+1)/2 elements.
+type SymDense[T number.Num] struct {
+	N    int
+	Data []T
+}
+
+// NewSymDense creates an n x n symmetric matrix of zeros.
+func NewSymDense[T number.Num](n int) (*SymDense[T], error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("dimensions of matrices must be above zero")
+	}
+	return &SymDense[T]{N: n, Data: make([]T, n*(n+1)/2)}, nil
+}
+
+// SymDenseFrom copies the square matrix m into a symmetric matrix. Returns an error
+// if m is not square or not exactly symmetric.
+func SymDenseFrom[T number.Num](m *Mat[T]) (*SymDense[T], error) {
+	if m.M != m.N {
+		return nil, shapeErrorf("SymDenseFrom", "need a square matrix, got %dx%d", m.M, m.N)
+	}
+
+	s, _ := NewSymDense[T](m.N)
+	for i := range m.M {
+		for j := i; j < m.N; j++ {
+			v := m.Data[i*m.N+j]
+			if w := m.Data[j*m.N+i]; w != v {
+				return nil, fmt.Errorf("matrix is not symmetric: elements (%d, %d) and (%d, %d) are %v and %v", i+1, j+1, j+1, i+1, v, w)
+			}
+			s.Data[packedUpper(s.N, i, j)] = v
+		}
+	}
+	return s, nil
+}
+
+// Dims returns the number of rows and columns of s.
+func (s *SymDense[T]) Dims() (rows, cols int) {
+	return s.N, s.N
+}
+
+// At returns the element at the 1-based row i and column j. Panics if the index is
+// out of range.
+func (s *SymDense[T]) At(i, j int) T {
+	checkSquareIndex(i, j, s.N)
+	return s.Data[s.index(i-1, j-1)]
+}
+
+// SetSym sets the elements at (i, j) and (j, i), with 1-based indices. Panics if the
+// index is out of range.
+func (s *SymDense[T]) SetSym(i, j int, v T) {
+	checkSquareIndex(i, j, s.N)
+	s.Data[s.index(i-1, j-1)] = v
+}
+
+// Dense returns a copy of s as a general matrix.
+func (s *SymDense[T]) Dense() *Mat[T] {
+	return toDense[T](s)
+}
+
+func (s *SymDense[T]) String() string {
+	return s.Dense().String()
+}
+
+func (s *SymDense[T]) index(i, j int) int {
+	if i > j {
+		i, j = j, i
+	}
+	return packedUpper(s.N, i, j)
+}
@@ -0,0 +1,99 @@
+package mat
+
+import (
+	"fmt"
+
+	"github.com/lattots/gonum/number"
+)
+
+// Transposed is the transpose of a matrix that reads the elements of the original
+// matrix instead of copying them, so changes to the original show through. Dot and
+// DotTrans multiply transposed dense matrices in place.
+type Transposed[T number.Num] struct {
+	m Matrix[T]
+}
+
+// T returns the transpose of a without copying it. The transpose of a Transposed is
+// the original matrix. Use Transpose for a copy.
+func T[T number.Num](a Matrix[T]) Matrix[T] {
+	if t, ok := a.(Transposed[T]); ok {
+		return t.m
+	}
+	return Transposed[T]{m: a}
+}
+
+// Dims returns the number of rows and columns, which are those of the original swapped.
+func (t Transposed[T]) Dims() (rows, cols int) {
+	cols, rows = t.m.Dims()
+	return rows, cols
+}
+
+// At returns the element at the 1-based row i and column j, which is element (j, i)
+// of the original.
+func (t Transposed[T]) At(i, j int) T {
+	return t.m.At(j, i)
+}
+
+// Untranspose returns the original matrix.
+func (t Transposed[T]) Untranspose() Matrix[T] {
+	return t.m
+}
+
+func (t Transposed[T]) String() string {
+	return DenseOf[T](t).String()
+}
+
+// DotTrans calculates op(a) op(b), where op transposes its argument if the matching
+// flag is set, like the transA and transB arguments of BLAS gemm. Dense operands are
+// read in place through strides, so products such as AᵀA and ABᵀ don't copy. Products
+// large enough for Strassen's algorithm are copied into padded blocks either way, and
+// then the transpose is done while copying. Summation follows Dot; use DotWith with
+// transposed operands from T to choose a strategy.
+func DotTrans[T number.Num](transA, transB bool, a, b Matrix[T]) (*Mat[T], error) {
+	m1, m2, transA, transB, err := transOperands(transA, transB, a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, inner, cols := m1.M, m1.N, m2.N
+	if transA {
+		rows, inner = inner, rows
+	}
+	if transB {
+		cols = m2.M
+	}
+	if min(rows, inner, cols) >= StrassenThreshold[T]() {
+		if transA {
+			m1 = Transpose(m1)
+		}
+		if transB {
+			m2 = Transpose(m2)
+		}
+		return Dot(m1, m2)
+	}
+	return dotNaiveTrans(m1, m2, transA, transB, CurrentSummation()), nil
+}
+
+// transOperands unwraps lazily transposed operands into the flags, which they flip,
+// and checks that op(a) and op(b) can be multiplied.
+func transOperands[T number.Num](transA, transB bool, a, b Matrix[T]) (*Mat[T], *Mat[T], bool, bool, error) {
+	if t, ok := a.(Transposed[T]); ok {
+		a, transA = t.m, !transA
+	}
+	if t, ok := b.(Transposed[T]); ok {
+		b, transB = t.m, !transB
+	}
+	m1, m2 := DenseOf(a), DenseOf(b)
+
+	inner, innerB := m1.N, m2.M
+	if transA {
+		inner = m1.M
+	}
+	if transB {
+		innerB = m2.N
+	}
+	if inner != innerB {
+		return nil, nil, false, false, fmt.Errorf("cannot multiply matrices: Number of columns in the first matrix (%d) must be equal to the number of rows in the second matrix (%d)", inner, innerB)
+	}
+	return m1, m2, transA, transB, nil
+}
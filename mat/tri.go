@@ -0,0 +1,152 @@
+package mat
+
+import (
+	"fmt"
+
+	"github.com/lattots/gonum/number"
+)
+
+// TriDense is an n x n upper or lower triangular matrix. Only the triangle is stored,
+// packed row by row, which takes n(n+1)/2 elements.
+type TriDense[T number.Num] struct {
+	N     int
+	Upper bool
+	Data  []T
+}
+
+// NewTriDense creates an n x n upper or lower triangular matrix of zeros.
+func NewTriDense[T number.Num](n int, upper bool) (*TriDense[T], error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("dimensions of matrices must be above zero")
+	}
+	return &TriDense[T]{N: n, Upper: upper, Data: make([]T, n*(n+1)/2)}, nil
+}
+
+// TriDenseFrom copies the square matrix m into a triangular matrix. Returns an error
+// if m is not square or has non-zero elements outside the triangle.
+func TriDenseFrom[T number.Num](m *Mat[T], upper bool) (*TriDense[T], error) {
+	if m.M != m.N {
+		return nil, shapeErrorf("TriDenseFrom", "need a square matrix, got %dx%d", m.M, m.N)
+	}
+
+	t, _ := NewTriDense[T](m.N, upper)
+	for i := range m.M {
+		for j := range m.N {
+			v := m.Data[i*m.N+j]
+			if (j >= i) == upper || i == j {
+				t.Data[t.index(i, j)] = v
+			} else if v != 0 {
+				return nil, fmt.Errorf("matrix is not triangular: element (%d, %d) is %v", i+1, j+1, v)
+			}
+		}
+	}
+	return t, nil
+}
+
+// Dims returns the number of rows and columns of t.
+func (t *TriDense[T]) Dims() (rows, cols int) {
+	return t.N, t.N
+}
+
+// At returns the element at the 1-based row i and column j, which is zero outside
+// the triangle. Panics if the index is out of range.
+func (t *TriDense[T]) At(i, j int) T {
+	checkSquareIndex(i, j, t.N)
+	if !t.inTriangle(i-1, j-1) {
+		return 0
+	}
+	return t.Data[t.index(i-1, j-1)]
+}
+
+// SetTri sets the element at the 1-based row i and column j. Panics if the index is
+// out of range or outside the triangle.
+func (t *TriDense[T]) SetTri(i, j int, v T) {
+	checkSquareIndex(i, j, t.N)
+	if !t.inTriangle(i-1, j-1) {
+		panic(fmt.Sprintf("matrix index error: index (%d, %d) is outside the triangle", i, j))
+	}
+	t.Data[t.index(i-1, j-1)] = v
+}
+
+// Dense returns a copy of t as a general matrix.
+func (t *TriDense[T]) Dense() *Mat[T] {
+	return toDense[T](t)
+}
+
+func (t *TriDense[T]) String() string {
+	return t.Dense().String()
+}
+
+func (t *TriDense[T]) inTriangle(i, j int) bool {
+	if t.Upper {
+		return j >= i
+	}
+	return j <= i
+}
+
+// index returns the position of the 0-based element (i, j) of the triangle in Data.
+func (t *TriDense[T]) index(i, j int) int {
+	if t.Upper {
+		return packedUpper(t.N, i, j)
+	}
+	return i*(i+1)/2 + j
+}
+
+// packedUpper returns the position of the 0-based element (i, j) with i <= j of an
+// upper triangle of order n packed row by row.
+func packedUpper(n, i, j int) int {
+	return i*n - i*(i-1)/2 + j - i
+}
+
+// SolveTri solves t X = b by forward or back substitution, in O(n²) operations per
+// column of b instead of the O(n³) of a general solve. Returns ErrSingular if an
+// element on the diagonal is zero.
+func SolveTri[T number.Float](t *TriDense[T], b *Mat[T]) (*Mat[T], error) {
+	if b.M != t.N {
+		return nil, shapeErrorf("SolveTri", "right-hand side has %d rows, need %d", b.M, t.N)
+	}
+	for i := range t.N {
+		if t.Data[t.index(i, i)] == 0 {
+			return nil, ErrSingular
+		}
+	}
+
+	n, cols := t.N, b.N
+	x := &Mat[T]{M: b.M, N: b.N, Data: append([]T(nil), b.Data...)}
+	for step := range n {
+		// Lower triangles are solved from the top, upper triangles from the bottom
+		i := step
+		if t.Upper {
+			i = n - 1 - step
+		}
+
+		row := x.Data[i*cols : (i+1)*cols]
+		for k := range n {
+			if k == i || !t.inTriangle(i, k) {
+				continue
+			}
+			l := t.Data[t.index(i, k)]
+			if l == 0 {
+				continue
+			}
+			known := x.Data[k*cols : (k+1)*cols]
+			for j := range row {
+				row[j] -= l * known[j]
+			}
+		}
+
+		d := t.Data[t.index(i, i)]
+		for j := range row {
+			row[j] /= d
+		}
+	}
+
+	return x, nil
+}
+
+// checkSquareIndex panics if the 1-based index (i, j) is outside an n x n matrix.
+func checkSquareIndex(i, j, n int) {
+	if i < 1 || i > n || j < 1 || j > n {
+		panic(fmt.Sprintf("matrix index error: index (%d, %d) out of range for %dx%d matrix", i, j, n, n))
+	}
+}
@@ -0,0 +1,254 @@
+package mat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/lattots/gonum/number"
+)
+
+// TuningEnv names the environment variable that overrides the default path of the
+// tuning file, see TuningPath.
+const TuningEnv = "GONUM_TUNING"
+
+// NoStrassen is a threshold that keeps Dot from ever using Strassen's algorithm.
+const NoStrassen = math.MaxInt
+
+// Tuning holds the Strassen crossover thresholds measured on a machine. A threshold is the
+// smallest dimension at which Dot uses Strassen's algorithm instead of the naive product,
+// and the size below which the Strassen recursion falls back to the naive product.
+type Tuning struct {
+	// Cores is the GOMAXPROCS value the thresholds were measured with.
+	Cores int `json:"cores"`
+	// Thresholds maps element type names such as "float64" to their crossover.
+	Thresholds map[string]int `json:"thresholds"`
+}
+
+// tuningFile is the layout of the tuning file, which keeps one Tuning per core count.
+type tuningFile struct {
+	Tunings []Tuning `json:"tunings"`
+}
+
+// Thresholds are indexed by the binary format tag of the element type. Overrides
+// take precedence over tuned values, and zero means unset.
+var (
+	thresholdOverrides [tagFloat64 + 1]atomic.Int64
+	tunedThresholds    atomic.Pointer[[tagFloat64 + 1]int]
+)
+
+// StrassenThreshold returns the crossover Dot uses for T. It is the value set with
+// SetStrassenThreshold if there is one, otherwise the value for the current GOMAXPROCS
+// from the last LoadTuning, otherwise 128. No tuning file is read unless the program
+// calls LoadTuning, so results don't depend on the environment it runs in.
+func StrassenThreshold[T number.Num]() int {
+	tag := binaryTag[T]()
+	if t := thresholdOverrides[tag].Load(); t > 0 {
+		return int(t)
+	}
+
+	if tuned := tunedThresholds.Load(); tuned != nil && tuned[tag] > 0 {
+		return tuned[tag]
+	}
+
+	return naiveThreshold
+}
+
+// SetStrassenThreshold overrides the crossover for T and returns the previous override,
+// or 0 if there was none. Setting 0 removes the override. Use NoStrassen to always use
+// the naive product. Panics if n is negative or below 2, the smallest size that splits.
+func SetStrassenThreshold[T number.Num](n int) int {
+	if n < 0 || n == 1 {
+		panic(fmt.Sprintf("matrix math error: invalid Strassen threshold %d", n))
+	}
+	return int(thresholdOverrides[binaryTag[T]()].Swap(int64(n)))
+}
+
+// TuningPath returns the default path of the tuning file: the value of GONUM_TUNING if
+// set, otherwise gonum/tuning.json in the user configuration directory. Programs that
+// want the tuned thresholds pass it to LoadTuning.
+func TuningPath() (string, error) {
+	if path := os.Getenv(TuningEnv); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gonum", "tuning.json"), nil
+}
+
+// LoadTuning reads the tuning file at path and applies the thresholds measured with
+// the current GOMAXPROCS. Files without a matching entry reset the tuned thresholds.
+// Every entry is validated, whatever its core count, and an invalid file leaves the
+// thresholds unchanged. Overrides set with SetStrassenThreshold still take precedence.
+// Programs load the file written by `gonum-bench tune` by passing the path from TuningPath,
+// see the package documentation.
+func LoadTuning(path string) error {
+	f, err := readTuningFile(path)
+	if err != nil {
+		return err
+	}
+
+	var tuned [tagFloat64 + 1]int
+	cores := runtime.GOMAXPROCS(0)
+	for _, t := range f.Tunings {
+		for name, n := range t.Thresholds {
+			tag, ok := tagByName[name]
+			if !ok {
+				return fmt.Errorf("unknown element type %q in tuning file", name)
+			}
+			if n < 2 {
+				return fmt.Errorf("invalid Strassen threshold %d for %s in tuning file", n, name)
+			}
+			if t.Cores == cores {
+				tuned[tag] = n
+			}
+		}
+	}
+
+	tunedThresholds.Store(&tuned)
+	return nil
+}
+
+// SaveTuning stores t in the tuning file at path, replacing an earlier tuning with
+// the same core count and keeping the others. Missing directories are created.
+func SaveTuning(path string, t Tuning) error {
+	f, err := readTuningFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	f.Tunings = slices.DeleteFunc(f.Tunings, func(old Tuning) bool { return old.Cores == t.Cores })
+	f.Tunings = append(f.Tunings, t)
+	slices.SortFunc(f.Tunings, func(a, b Tuning) int { return a.Cores - b.Cores })
+
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+func readTuningFile(path string) (tuningFile, error) {
+	var f tuningFile
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return f, err
+	}
+	if err := json.Unmarshal(b, &f); err != nil {
+		return f, fmt.Errorf("failed to read tuning file %s: %w", path, err)
+	}
+	return f, nil
+}
+
+var tagByName = map[string]byte{
+	"int8":    tagInt8,
+	"int16":   tagInt16,
+	"int32":   tagInt32,
+	"int64":   tagInt64,
+	"int":     tagInt,
+	"float32": tagFloat32,
+	"float64": tagFloat64,
+}
+
+// tuneSizes are the matrix sizes at which the tuner compares the two algorithms.
+var tuneSizes = []int{32, 48, 64, 96, 128, 192, 256, 384, 512, 768, 1024, 1536, 2048}
+
+// TuneStrassen measures the Strassen crossover of every element type with the current
+// GOMAXPROCS. At each size up to maxSize it times the naive product against one level
+// of Strassen's algorithm on top of it, padding like Dot does. The crossover is the
+// first size from which Strassen wins at that size and the next one, or NoStrassen if
+// it never does. Tuning with a maxSize of 1024 takes in the order of a minute.
+// Save the result with SaveTuning and apply it with LoadTuning.
+func TuneStrassen(maxSize int) Tuning {
+	return Tuning{
+		Cores: runtime.GOMAXPROCS(0),
+		Thresholds: map[string]int{
+			"int":     tuneType[int](maxSize),
+			"int8":    tuneType[int8](maxSize),
+			"int16":   tuneType[int16](maxSize),
+			"int32":   tuneType[int32](maxSize),
+			"int64":   tuneType[int64](maxSize),
+			"float32": tuneType[float32](maxSize),
+			"float64": tuneType[float64](maxSize),
+		},
+	}
+}
+
+func tuneType[T number.Num](maxSize int) int {
+	var wins []int
+	for _, n := range tuneSizes {
+		if n > maxSize {
+			break
+		}
+
+		m1, m2 := tuneMatrix[T](n), tuneMatrix[T](n)
+		naive := timeDot(func() { dotNaive(m1, m2) })
+		strassen := timeDot(func() {
+			sq1, sq2 := Square(m1, m2)
+			// Recurse exactly once, down to half the padded size
+			dotStrassen(sq1, sq2, sq1.M/2)
+		})
+		if strassen < naive {
+			wins = append(wins, n)
+		} else {
+			wins = wins[:0]
+		}
+
+		if len(wins) == 2 {
+			return wins[0]
+		}
+	}
+
+	// A win at the largest size is taken as is, as there is nothing to confirm it with
+	if len(wins) == 1 {
+		return wins[0]
+	}
+	return NoStrassen
+}
+
+func tuneMatrix[T number.Num](n int) *Mat[T] {
+	data := make([]T, n*n)
+	for i := range data {
+		data[i] = T(i%7 - 3)
+	}
+	return &Mat[T]{M: n, N: n, Data: data}
+}
+
+// timeDot returns the fastest of a few runs of fn, which filters out interruptions.
+// Small products are repeated until each run takes at least a few milliseconds.
+func timeDot(fn func()) time.Duration {
+	reps := 1
+	for {
+		start := time.Now()
+		for range reps {
+			fn()
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Millisecond || reps >= 1<<16 {
+			break
+		}
+		reps *= 2
+	}
+
+	best := time.Duration(math.MaxInt64)
+	for range 3 {
+		start := time.Now()
+		for range reps {
+			fn()
+		}
+		best = min(best, time.Since(start))
+	}
+	return best / time.Duration(reps)
+}
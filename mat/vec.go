@@ -0,0 +1,254 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lattots/gonum/number"
+)
+
+// Vec is a vector of numbers. Unlike a Mat with a single row or column it has no
+// orientation, so operations only need to check that lengths match. RowMat and
+// ColMat view a Vec as a matrix without copying the data.
+type Vec[T number.Num] struct {
+	Data []T
+}
+
+// NewVec creates a vector holding a copy of data.
+func NewVec[T number.Num](data []T) (*Vec[T], error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("can't initialize a vector with no data")
+	}
+
+	d := make([]T, len(data))
+	copy(d, data)
+	return &Vec[T]{Data: d}, nil
+}
+
+// ZerosVec creates a vector of n zeros.
+func ZerosVec[T number.Num](n int) (*Vec[T], error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("length of vectors must be above zero")
+	}
+	return &Vec[T]{Data: make([]T, n)}, nil
+}
+
+// VecFromMat returns the elements of a row or column matrix as a vector sharing
+// the data of m.
+func VecFromMat[T number.Num](m *Mat[T]) (*Vec[T], error) {
+	if !m.IsVector() {
+		return nil, fmt.Errorf("cannot convert a %dx%d matrix to a vector", m.M, m.N)
+	}
+	return &Vec[T]{Data: m.Data}, nil
+}
+
+// Len returns the number of elements in v.
+func (v *Vec[T]) Len() int {
+	return len(v.Data)
+}
+
+// AtVec returns the element at the 1-based index i, like Mat.At.
+func (v *Vec[T]) AtVec(i int) T {
+	return v.Data[i-1]
+}
+
+// SetVec sets the element at the 1-based index i.
+func (v *Vec[T]) SetVec(i int, val T) {
+	v.Data[i-1] = val
+}
+
+// RowMat returns v as a 1xn matrix sharing its data.
+func (v *Vec[T]) RowMat() *Mat[T] {
+	return &Mat[T]{M: 1, N: len(v.Data), Data: v.Data}
+}
+
+// ColMat returns v as an nx1 matrix sharing its data.
+func (v *Vec[T]) ColMat() *Mat[T] {
+	return &Mat[T]{M: len(v.Data), N: 1, Data: v.Data}
+}
+
+// String formats v like a row matrix.
+func (v *Vec[T]) String() string {
+	return v.RowMat().String()
+}
+
+// Norm calculates the p-norm of v, (Σ|vᵢ|ᵖ)^(1/p). p is 1 for the sum of absolute values,
+// 2 for the Euclidean length and math.Inf(1) for the largest absolute value.
+// Panics if p is less than 1.
+func (v *Vec[T]) Norm(p float64) float64 {
+	return pNorm(len(v.Data), func(i int) float64 { return float64(v.Data[i]) }, p)
+}
+
+// pNorm calculates the p-norm of the n values returned by at.
+func pNorm(n int, at func(i int) float64, p float64) float64 {
+	if !(p >= 1) {
+		panic(fmt.Sprintf("matrix math error: p-norm needs p >= 1, got %g", p))
+	}
+
+	var res float64
+	for i := 0; i < n; i++ {
+		x := math.Abs(at(i))
+		switch {
+		case math.IsInf(p, 1):
+			res = math.Max(res, x)
+		case p == 1:
+			res += x
+		case p == 2:
+			res += x * x
+		default:
+			res += math.Pow(x, p)
+		}
+	}
+
+	switch {
+	case math.IsInf(p, 1), p == 1:
+		return res
+	case p == 2:
+		return math.Sqrt(res)
+	default:
+		return math.Pow(res, 1/p)
+	}
+}
+
+// checkLen panics if v and w have different lengths.
+func (v *Vec[T]) checkLen(op string, w *Vec[T]) {
+	if len(v.Data) != len(w.Data) {
+		panic(fmt.Sprintf("matrix math error: vector lengths must match for %s, got %d and %d", op, len(v.Data), len(w.Data)))
+	}
+}
+
+// Dot calculates the dot product of v and w. Floats are accumulated using the
+// strategy set with SetSummation.
+func (v *Vec[T]) Dot(w *Vec[T]) T {
+	return v.DotWith(w, CurrentSummation())
+}
+
+// DotWith is like Dot but uses the summation strategy s. Panics if s is not one of the
+// defined strategies.
+func (v *Vec[T]) DotWith(w *Vec[T], s Summation) T {
+	checkSummation(s)
+	v.checkLen("a dot product", w)
+	return dotStrided(v.Data, 0, 1, w.Data, 0, 1, len(v.Data), s)
+}
+
+// Axpy adds alpha*x to v in place.
+func (v *Vec[T]) Axpy(alpha T, x *Vec[T]) {
+	v.checkLen("axpy", x)
+	for i, xi := range x.Data {
+		v.Data[i] += alpha * xi
+	}
+}
+
+// Outer calculates the outer product v wᵀ, a matrix with v.Len() rows and w.Len() columns.
+func (v *Vec[T]) Outer(w *Vec[T]) *Mat[T] {
+	data := make([]T, len(v.Data)*len(w.Data))
+	for i, vi := range v.Data {
+		row := data[i*len(w.Data) : (i+1)*len(w.Data)]
+		for j, wj := range w.Data {
+			row[j] = vi * wj
+		}
+	}
+	return &Mat[T]{M: len(v.Data), N: len(w.Data), Data: data}
+}
+
+// Project returns the projection of v onto the direction of onto. Integer results are
+// truncated like in Normalize. Panics if onto is a zero vector.
+func (v *Vec[T]) Project(onto *Vec[T]) *Vec[T] {
+	v.checkLen("a projection", onto)
+
+	var vu, uu float64
+	for i, u := range onto.Data {
+		vu += float64(v.Data[i]) * float64(u)
+		uu += float64(u) * float64(u)
+	}
+	if uu == 0 {
+		panic("matrix math error: cannot project onto a vector of length 0")
+	}
+
+	scale := vu / uu
+	data := make([]T, len(onto.Data))
+	for i, u := range onto.Data {
+		data[i] = T(scale * float64(u))
+	}
+	return &Vec[T]{Data: data}
+}
+
+// CosineSimilarity calculates the cosine of the angle between v and w.
+// Panics if either is a zero vector.
+func (v *Vec[T]) CosineSimilarity(w *Vec[T]) float64 {
+	v.checkLen("cosine similarity", w)
+
+	len1, len2 := v.Norm(2), w.Norm(2)
+	if len1 == 0 || len2 == 0 {
+		panic("matrix math error: cannot calculate cosine similarity for a vector of length 0")
+	}
+
+	var dot float64
+	for i := range v.Data {
+		dot += float64(v.Data[i]) * float64(w.Data[i])
+	}
+	return dot / (len1 * len2)
+}
+
+// Angle calculates the angle between v and w in radians, in the range [0, π].
+// Panics if either is a zero vector.
+func (v *Vec[T]) Angle(w *Vec[T]) float64 {
+	// Rounding can push the cosine of nearly parallel vectors slightly past ±1
+	return math.Acos(math.Max(-1, math.Min(1, v.CosineSimilarity(w))))
+}
+
+// Lerp interpolates linearly between v at t = 0 and w at t = 1. Values of t outside
+// [0, 1] extrapolate. Integer results are truncated like in Normalize.
+func (v *Vec[T]) Lerp(w *Vec[T], t float64) *Vec[T] {
+	v.checkLen("interpolation", w)
+
+	data := make([]T, len(v.Data))
+	for i := range data {
+		a, b := float64(v.Data[i]), float64(w.Data[i])
+		data[i] = T(a + t*(b-a))
+	}
+	return &Vec[T]{Data: data}
+}
+
+// EuclideanDistance calculates the straight line distance between v and w.
+func (v *Vec[T]) EuclideanDistance(w *Vec[T]) float64 {
+	return v.MinkowskiDistance(w, 2)
+}
+
+// ManhattanDistance calculates the sum of the absolute differences of v and w.
+func (v *Vec[T]) ManhattanDistance(w *Vec[T]) float64 {
+	return v.MinkowskiDistance(w, 1)
+}
+
+// ChebyshevDistance calculates the largest absolute difference of v and w.
+func (v *Vec[T]) ChebyshevDistance(w *Vec[T]) float64 {
+	return v.MinkowskiDistance(w, math.Inf(1))
+}
+
+// MinkowskiDistance calculates the p-norm of v - w, see Norm. Differences are taken in
+// float64, so integer vectors can't overflow. Panics if p is less than 1.
+func (v *Vec[T]) MinkowskiDistance(w *Vec[T], p float64) float64 {
+	v.checkLen("a distance", w)
+	return pNorm(len(v.Data), func(i int) float64 { return float64(v.Data[i]) - float64(w.Data[i]) }, p)
+}
+
+// MulVec calculates the matrix-vector product m v. Floats are accumulated using the
+// strategy set with SetSummation.
+func MulVec[T number.Num](m *Mat[T], v *Vec[T]) (*Vec[T], error) {
+	return MulVecWith(m, v, CurrentSummation())
+}
+
+// MulVecWith is like MulVec but uses the summation strategy s. Panics if s is not one
+// of the defined strategies.
+func MulVecWith[T number.Num](m *Mat[T], v *Vec[T], s Summation) (*Vec[T], error) {
+	checkSummation(s)
+	if m.N != len(v.Data) {
+		return nil, fmt.Errorf("cannot multiply matrix and vector: Number of columns in the matrix (%d) must be equal to the length of the vector (%d)", m.N, len(v.Data))
+	}
+
+	data := make([]T, m.M)
+	for i := range data {
+		data[i] = dotStrided(m.Data, i*m.N, 1, v.Data, 0, 1, m.N, s)
+	}
+	return &Vec[T]{Data: data}, nil
+}
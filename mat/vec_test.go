@@ -0,0 +1,165 @@
+package mat_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
+)
+
+func TestVec(t *testing.T) {
+	start := time.Now()
+
+	v, err := mat.NewVec([]float64{3, -4, 12})
+	if err != nil {
+		t.Fatalf("Error creating vector: %v", err)
+	}
+
+	// Test case 1: Access and conversion share the data
+	if v.Len() != 3 || v.AtVec(2) != -4 {
+		t.Errorf("Wrong length or element. Got: %d, %g", v.Len(), v.AtVec(2))
+	}
+	row, col := v.RowMat(), v.ColMat()
+	if row.M != 1 || row.N != 3 || col.M != 3 || col.N != 1 {
+		t.Errorf("Wrong conversion dimensions. Row: %dx%d, Column: %dx%d", row.M, row.N, col.M, col.N)
+	}
+	v.SetVec(1, 5)
+	if col.At(1, 1) != 5 {
+		t.Errorf("Column matrix doesn't share the vector data")
+	}
+	back, err := mat.VecFromMat(row)
+	if err != nil || back.AtVec(1) != 5 {
+		t.Errorf("Wrong conversion back to vector: %v, %v", back, err)
+	}
+	square, _ := mat.Zeros[float64](2, 2)
+	if _, err := mat.VecFromMat(square); err == nil {
+		t.Errorf("Expected error converting a 2x2 matrix to a vector, but got nil")
+	}
+	v.SetVec(1, 3)
+
+	// Test case 2: Norms
+	testCases := []struct {
+		p        float64
+		expected float64
+	}{
+		{1, 19},
+		{2, 13},
+		{3, math.Cbrt(27 + 64 + 1728)},
+		{math.Inf(1), 12},
+	}
+	for _, tc := range testCases {
+		if result := v.Norm(tc.p); math.Abs(result-tc.expected) > 1e-12 {
+			t.Errorf("Wrong %g-norm. Want: %g, Got: %g", tc.p, tc.expected, result)
+		}
+	}
+
+	// Test case 3: Dot and outer products
+	w, _ := mat.NewVec([]float64{1, 2, 3})
+	if result := v.Dot(w); result != 31 {
+		t.Errorf("Wrong dot product. Want: 31, Got: %g", result)
+	}
+	outer := w.Outer(&mat.Vec[float64]{Data: []float64{1, -1}})
+	expected, _ := mat.New([][]float64{{1, -1}, {2, -2}, {3, -3}})
+	mattest.AssertEqual(t, outer, expected, mattest.Tolerance{})
+
+	// Test case 4: Axpy updates in place
+	v.Axpy(2, w)
+	if v.Data[0] != 5 || v.Data[1] != 0 || v.Data[2] != 18 {
+		t.Errorf("Wrong result of axpy: %v", v.Data)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Dot to panic on length mismatch, but it did not")
+		}
+	}()
+	v.Dot(&mat.Vec[float64]{Data: []float64{1}})
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestVecGeometry(t *testing.T) {
+	start := time.Now()
+
+	x, _ := mat.NewVec([]float64{1, 0})
+	y, _ := mat.NewVec([]float64{0, 2})
+	d, _ := mat.NewVec([]float64{3, 3})
+
+	if result := x.Angle(y); math.Abs(result-math.Pi/2) > 1e-15 {
+		t.Errorf("Wrong angle. Want: %g, Got: %g", math.Pi/2, result)
+	}
+	if result := d.Angle(d); result != 0 {
+		t.Errorf("Wrong angle of a vector with itself. Want: 0, Got: %g", result)
+	}
+	if result := x.CosineSimilarity(d); math.Abs(result-math.Sqrt2/2) > 1e-15 {
+		t.Errorf("Wrong cosine similarity. Want: %g, Got: %g", math.Sqrt2/2, result)
+	}
+
+	if p := d.Project(x); p.Data[0] != 3 || p.Data[1] != 0 {
+		t.Errorf("Wrong projection. Want: [3 0], Got: %v", p.Data)
+	}
+	if l := x.Lerp(d, 0.5); l.Data[0] != 2 || l.Data[1] != 1.5 {
+		t.Errorf("Wrong interpolation. Want: [2 1.5], Got: %v", l.Data)
+	}
+
+	// Distances between (1, 0) and (3, 3)
+	if result := x.EuclideanDistance(d); result != math.Sqrt(13) {
+		t.Errorf("Wrong Euclidean distance. Want: %g, Got: %g", math.Sqrt(13), result)
+	}
+	if result := x.ManhattanDistance(d); result != 5 {
+		t.Errorf("Wrong Manhattan distance. Want: 5, Got: %g", result)
+	}
+	if result := x.ChebyshevDistance(d); result != 3 {
+		t.Errorf("Wrong Chebyshev distance. Want: 3, Got: %g", result)
+	}
+	if result := x.MinkowskiDistance(d, 3); math.Abs(result-math.Cbrt(35)) > 1e-12 {
+		t.Errorf("Wrong Minkowski distance. Want: %g, Got: %g", math.Cbrt(35), result)
+	}
+
+	// Integer differences don't overflow
+	a := &mat.Vec[int8]{Data: []int8{-128}}
+	b := &mat.Vec[int8]{Data: []int8{127}}
+	if result := a.ManhattanDistance(b); result != 255 {
+		t.Errorf("Wrong int8 distance. Want: 255, Got: %g", result)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Project to panic for a zero vector, but it did not")
+		}
+	}()
+	x.Project(&mat.Vec[float64]{Data: []float64{0, 0}})
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
+
+func TestMulVec(t *testing.T) {
+	start := time.Now()
+
+	m, _ := mat.New([][]int{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	v, _ := mat.NewVec([]int{1, 0, -1})
+
+	result, err := mat.MulVec(m, v)
+	if err != nil {
+		t.Fatalf("Error multiplying matrix and vector: %v", err)
+	}
+	if result.Len() != 2 || result.Data[0] != -2 || result.Data[1] != -2 {
+		t.Errorf("Wrong matrix-vector product. Want: [-2 -2], Got: %v", result.Data)
+	}
+
+	// Same as multiplying with the column matrix
+	expected, _ := mat.Dot(m, v.ColMat())
+	mattest.AssertEqual(t, result.ColMat(), expected, mattest.Tolerance{})
+
+	if _, err := mat.MulVec(mat.Transpose(m), v); err == nil {
+		t.Errorf("Expected error multiplying 3x2 matrix and 3-vector, but got nil")
+	}
+
+	fmt.Printf("Runtime: %v\n", time.Since(start))
+}
@@ -37,7 +37,16 @@ func Normalize[T number.Num](v *Mat[T]) *Mat[T] {
 }
 
 // VectorDot computes the vector dot product (returning a scalar value).
+// Floats are accumulated using the strategy set with SetSummation.
+// For vectors that don't need a matrix orientation use Vec.Dot.
 func VectorDot[T number.Num](v1, v2 *Mat[T]) T {
+	return VectorDotWith(v1, v2, CurrentSummation())
+}
+
+// VectorDotWith is like VectorDot but uses the summation strategy s. Panics if s is not
+// one of the defined strategies.
+func VectorDotWith[T number.Num](v1, v2 *Mat[T], s Summation) T {
+	checkSummation(s)
 	if !v1.IsVector() || !v2.IsVector() {
 		panic("matrix math error: inputs must be vectors for a vector dot product")
 	}
@@ -45,11 +54,7 @@ func VectorDot[T number.Num](v1, v2 *Mat[T]) T {
 		panic("matrix math error: vector dimensions must match for a dot product")
 	}
 
-	var sum T
-	for i := range v1.Data {
-		sum += v1.Data[i] * v2.Data[i]
-	}
-	return sum
+	return dotStrided(v1.Data, 0, 1, v2.Data, 0, 1, len(v1.Data), s)
 }
 
 // CrossProduct calculates the 3D cross product of two 3-element vectors.
@@ -70,6 +75,8 @@ func CrossProduct[T number.Num](v1, v2 *Mat[T]) *Mat[T] {
 	}
 }
 
+// CosineSimilarity calculates the cosine of the angle between two vector-shaped matrices.
+// For vectors that don't need a matrix orientation use Vec.CosineSimilarity.
 func CosineSimilarity[T number.Num](v1, v2 *Mat[T]) float64 {
 	if !v1.IsVector() || !v2.IsVector() {
 		panic("matrix math error: inputs must be vector-shaped matrices for cosine similarity")
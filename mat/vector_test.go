@@ -6,8 +6,8 @@ import (
 	"testing"
 	"time"
 
-	"github.com/lattots/gonum/internal/util"
 	"github.com/lattots/gonum/mat"
+	"github.com/lattots/gonum/mat/mattest"
 )
 
 func TestVectorLength(t *testing.T) {
@@ -47,7 +47,7 @@ func TestVectorNormalize(t *testing.T) {
 	expected, _ := mat.New([][]float64{{0.6, 0.8}})
 	result := mat.Normalize(m)
 
-	if !util.EqualMatrix(result, expected) {
+	if !mattest.EqualMatrix(result, expected) {
 		t.Errorf("Wrong result in vector normalization. Want: %s\nGot: %s", expected, result)
 	}
 
@@ -100,7 +100,7 @@ func TestVectorCrossProduct(t *testing.T) {
 	result := mat.CrossProduct(v1, v2)
 	expected, _ := mat.New([][]float64{{-3}, {6}, {-3}})
 
-	if !util.EqualMatrix(result, expected) {
+	if !mattest.EqualMatrix(result, expected) {
 		t.Errorf("Wrong result in cross product. Want: %s\nGot: %s", expected, result)
 	}
 